@@ -0,0 +1,50 @@
+package daterange
+
+// BackfillOrder is the order of the chunks returned by PlanBackfill.
+type BackfillOrder int
+
+const (
+	// OldestFirst returns the oldest chunks first. Every gap is cut starting from its first date.
+	OldestFirst BackfillOrder = iota
+	// NewestFirst returns the newest chunks first. Every gap is cut starting from its last date.
+	NewestFirst
+)
+
+// PlanBackfill returns the dates of expected that are not in present, split into
+// chunks of at most maxDays days, in the given order. Chunks never span two gaps.
+// A maxDays less than 1 returns every gap as a single chunk.
+// An unbounded expected range returns no chunks.
+func PlanBackfill(expected DateRange, present DateRanges, maxDays int, order BackfillOrder) []DateRange {
+	chunks := []DateRange{}
+	if !expected.IsBounded() {
+		return chunks
+	}
+	missing := present.Complement(expected)
+	// chunks longer than a gap are the whole gap, clamping also avoids overflows
+	chunkDays := func(gap DateRange) int {
+		if days := gap.Days(); maxDays < 1 || maxDays > days {
+			return days
+		}
+		return maxDays
+	}
+	if order == OldestFirst {
+		for _, gap := range missing.dr {
+			chunks = append(chunks, gap.SplitEvery(chunkDays(gap))...)
+		}
+		return chunks
+	}
+
+	for i := len(missing.dr) - 1; i >= 0; i-- {
+		gap := missing.dr[i]
+		size := chunkDays(gap)
+		// cut from the last date, so the newest chunk is a full one
+		for to := gap.to; !to.Before(gap.from); to = to.AddDate(0, 0, -size) {
+			chunks = append(chunks, DateRange{
+				from:     maxTime(to.AddDate(0, 0, 1-size), gap.from),
+				to:       to,
+				nonEmpty: true,
+			})
+		}
+	}
+	return chunks
+}
@@ -0,0 +1,127 @@
+package daterange_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// test dr.PlanBackfill
+func TestPlanBackfill(t *testing.T) {
+	expected := dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+	present := dr.NewDateRanges(
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+	)
+	cases := []struct {
+		name     string
+		expected dr.DateRange
+		present  dr.DateRanges
+		maxDays  int
+		order    dr.BackfillOrder
+		want     []dr.DateRange
+	}{
+		{
+			name:     "nothing missing",
+			expected: expected,
+			present:  dr.NewDateRanges(expected),
+			maxDays:  3,
+			order:    dr.OldestFirst,
+			want:     []dr.DateRange{},
+		},
+		{
+			name:     "whole gaps oldest first",
+			expected: expected,
+			present:  present,
+			maxDays:  0,
+			order:    dr.OldestFirst,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "whole gaps newest first",
+			expected: expected,
+			present:  present,
+			maxDays:  0,
+			order:    dr.NewestFirst,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "huge chunks oldest first",
+			expected: expected,
+			present:  present,
+			maxDays:  math.MaxInt,
+			order:    dr.OldestFirst,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "huge chunks newest first",
+			expected: expected,
+			present:  present,
+			maxDays:  math.MaxInt,
+			order:    dr.NewestFirst,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "chunks oldest first",
+			expected: expected,
+			present:  present,
+			maxDays:  4,
+			order:    dr.OldestFirst,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 24, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 25, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 28, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "chunks newest first",
+			expected: expected,
+			present:  present,
+			maxDays:  4,
+			order:    dr.NewestFirst,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 28, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 24, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 27, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 23, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "present outside expected is ignored",
+			expected: dr.NewDateRange(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+			present:  present,
+			maxDays:  10,
+			order:    dr.OldestFirst,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := dr.PlanBackfill(c.expected, c.present, c.maxDays, c.order)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("PlanBackfill(%v, %v, %v, %v) = %v, want %v", c.expected, c.present, c.maxDays, c.order, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package daterange
+
+import (
+	"math"
+	"time"
+)
+
+// BillingCycle generates consecutive billing periods starting on an anchor date.
+//
+// For monthly, quarterly and annual cycles every period starts on the day of
+// month of the anchor. When a month is too short the period starts on its last
+// day instead, and the following periods return to the anchor day, so an anchor
+// on January 31st gives periods starting on February 29th, March 31st, April 30th
+// and so on. Every start date is computed from the anchor, so it never drifts.
+//
+// The zero value generates no periods.
+type BillingCycle struct {
+	anchor time.Time
+	months int // length of every period in months, 0 for weekly cycles
+	days   int // length of every period in days, 0 for monthly cycles
+}
+
+// MonthlyCycle returns a BillingCycle renewing every month on the day of the anchor.
+// Note: Only the date portion of the time.Time value is used. The time portion is ignored.
+func MonthlyCycle(anchor time.Time) BillingCycle {
+	return BillingCycle{anchor: toDateUTC(anchor), months: 1}
+}
+
+// QuarterlyCycle returns a BillingCycle renewing every three months on the day of the anchor.
+// Note: Only the date portion of the time.Time value is used. The time portion is ignored.
+func QuarterlyCycle(anchor time.Time) BillingCycle {
+	return BillingCycle{anchor: toDateUTC(anchor), months: 3}
+}
+
+// AnnualCycle returns a BillingCycle renewing every year on the date of the anchor.
+// An anchor on February 29th renews on February 28th in other years.
+// Note: Only the date portion of the time.Time value is used. The time portion is ignored.
+func AnnualCycle(anchor time.Time) BillingCycle {
+	return BillingCycle{anchor: toDateUTC(anchor), months: 12}
+}
+
+// WeeklyCycle returns a BillingCycle renewing every n weeks on the weekday of
+// the anchor. A zero BillingCycle is returned if n is less than 1, or so large
+// that the length of a period in days overflows an int.
+// Note: Only the date portion of the time.Time value is used. The time portion is ignored.
+func WeeklyCycle(anchor time.Time, n int) BillingCycle {
+	if n < 1 || n > math.MaxInt/7 {
+		return BillingCycle{}
+	}
+	return BillingCycle{anchor: toDateUTC(anchor), days: 7 * n}
+}
+
+// IsZero returns true if the cycle generates no periods
+func (c BillingCycle) IsZero() bool {
+	return c.months == 0 && c.days == 0
+}
+
+// Period returns the n-th billing period, counting from 0 for the period
+// starting on the anchor. It returns an empty DateRange if n is negative.
+func (c BillingCycle) Period(n int) DateRange {
+	if c.IsZero() || n < 0 {
+		return DateRange{}
+	}
+	return DateRange{
+		from:     c.start(n),
+		to:       c.start(n+1).AddDate(0, 0, -1),
+		nonEmpty: true,
+	}
+}
+
+// PeriodContaining returns the billing period containing the given date.
+// It returns an empty DateRange if the date is before the anchor.
+func (c BillingCycle) PeriodContaining(date time.Time) DateRange {
+	n, ok := c.index(toDateUTC(date))
+	if !ok {
+		return DateRange{}
+	}
+	return c.Period(n)
+}
+
+// Periods returns an iterator over the whole billing periods overlapping the
+// given window, in ascending order. Periods are not clipped to the window.
+//
+// The iterator has the signature of iter.Seq[DateRange] and can be used with
+// range-over-func on Go 1.23 or later, or called directly with a yield function
+// that returns false to stop the iteration.
+func (c BillingCycle) Periods(window DateRange) func(yield func(DateRange) bool) {
+	return func(yield func(DateRange) bool) {
+		if c.IsZero() || window.IsEmpty() || window.to.Before(c.anchor) {
+			return
+		}
+		n, _ := c.index(maxTime(window.from, c.anchor))
+		for period := c.Period(n); !period.from.After(window.to); period = c.Period(n) {
+			if !yield(period) {
+				return
+			}
+			n++
+		}
+	}
+}
+
+// start returns the first date of the n-th period.
+func (c BillingCycle) start(n int) time.Time {
+	if c.months > 0 {
+		return addMonthsClamped(c.anchor, n*c.months)
+	}
+	return c.anchor.AddDate(0, 0, n*c.days)
+}
+
+// index returns the number of the period containing the given date.
+// It returns false if the date is before the anchor.
+func (c BillingCycle) index(date time.Time) (int, bool) {
+	if c.IsZero() || date.Before(c.anchor) {
+		return 0, false
+	}
+	if c.days > 0 {
+		return daysBetween(c.anchor, date) / c.days, true
+	}
+	months := (date.Year()-c.anchor.Year())*12 + int(date.Month()-c.anchor.Month())
+	n := months / c.months
+	// the estimate is off by one when the date is before the anchor day of its month
+	if n > 0 && c.start(n).After(date) {
+		n--
+	}
+	return n, true
+}
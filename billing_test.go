@@ -0,0 +1,190 @@
+package daterange_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// test dr.BillingCycle.Periods
+func TestBillingCyclePeriods(t *testing.T) {
+	cases := []struct {
+		name   string
+		cycle  dr.BillingCycle
+		window dr.DateRange
+		want   []dr.DateRange
+	}{
+		{
+			name:   "zero cycle",
+			cycle:  dr.BillingCycle{},
+			window: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)),
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "zero window",
+			cycle:  dr.MonthlyCycle(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)),
+			window: dr.DateRange{},
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "window before anchor",
+			cycle:  dr.MonthlyCycle(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)),
+			window: dr.NewDateRange(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)),
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "monthly anchored on the 31st does not drift",
+			cycle:  dr.MonthlyCycle(time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)),
+			window: dr.NewDateRange(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 30, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:   "quarterly, window in the middle of a period",
+			cycle:  dr.QuarterlyCycle(time.Date(2023, 11, 30, 0, 0, 0, 0, time.UTC)),
+			window: dr.NewDateRange(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 29, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 5, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 8, 29, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:   "annual anchored on leap day",
+			cycle:  dr.AnnualCycle(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+			window: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, 3, 1, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 27, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2027, 2, 27, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2027, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2028, 2, 28, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2029, 2, 27, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:   "every two weeks",
+			cycle:  dr.WeeklyCycle(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), 2),
+			window: dr.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:   "invalid weeks",
+			cycle:  dr.WeeklyCycle(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), 0),
+			window: dr.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC)),
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "overflowing weeks",
+			cycle:  dr.WeeklyCycle(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), math.MaxInt/7+1),
+			window: dr.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 5, 0, 0, 0, 0, time.UTC)),
+			want:   []dr.DateRange{},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := []dr.DateRange{}
+			c.cycle.Periods(c.window)(func(period dr.DateRange) bool {
+				got = append(got, period)
+				return true
+			})
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Periods(%v) = %v, want %v", c.window, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.BillingCycle.PeriodContaining
+func TestBillingCyclePeriodContaining(t *testing.T) {
+	cases := []struct {
+		name  string
+		cycle dr.BillingCycle
+		date  time.Time
+		want  dr.DateRange
+	}{
+		{
+			name:  "before anchor",
+			cycle: dr.MonthlyCycle(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			date:  time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC),
+			want:  dr.DateRange{},
+		},
+		{
+			name:  "on anchor",
+			cycle: dr.MonthlyCycle(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			date:  time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			want:  dr.NewDateRange(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:  "before the anchor day of the month",
+			cycle: dr.MonthlyCycle(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			date:  time.Date(2025, 6, 29, 23, 0, 0, 0, time.UTC),
+			want:  dr.NewDateRange(time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 29, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:  "on a clamped start",
+			cycle: dr.MonthlyCycle(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			date:  time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC),
+			want:  dr.NewDateRange(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:  "quarterly",
+			cycle: dr.QuarterlyCycle(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)),
+			date:  time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC),
+			want:  dr.NewDateRange(time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:  "weekly",
+			cycle: dr.WeeklyCycle(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 1),
+			date:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			want:  dr.NewDateRange(time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:  "zero cycle",
+			cycle: dr.BillingCycle{},
+			date:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			want:  dr.DateRange{},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.cycle.PeriodContaining(c.date); got != c.want {
+				t.Errorf("PeriodContaining(%v) = %v, want %v", c.date, got, c.want)
+			}
+		})
+	}
+}
+
+// test that consecutive dr.BillingCycle periods are contiguous and contain their dates
+func TestBillingCycleContiguous(t *testing.T) {
+	cycles := []dr.BillingCycle{
+		dr.MonthlyCycle(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+		dr.MonthlyCycle(time.Date(2024, 1, 29, 0, 0, 0, 0, time.UTC)),
+		dr.QuarterlyCycle(time.Date(2024, 8, 31, 0, 0, 0, 0, time.UTC)),
+		dr.AnnualCycle(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+		dr.WeeklyCycle(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), 4),
+	}
+	for _, cycle := range cycles {
+		for n := 0; n < 100; n++ {
+			period, next := cycle.Period(n), cycle.Period(n+1)
+			if !period.To().AddDate(0, 0, 1).Equal(next.From()) {
+				t.Fatalf("%+v: Period(%d) = %v is not followed by Period(%d) = %v", cycle, n, period, n+1, next)
+			}
+			for _, date := range []time.Time{period.From(), period.To()} {
+				if got := cycle.PeriodContaining(date); got != period {
+					t.Fatalf("%+v: PeriodContaining(%v) = %v, want %v", cycle, date, got, period)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package daterange
+
+import (
+	"container/heap"
+	"sort"
+	"time"
+)
+
+// Calendar holds the busy dates of a set of resources, such as the absences of
+// employees or the occupancy of rooms, as a DateRanges per key. Keys are kept in
+// the order they were first added. The zero value is an empty calendar ready to use.
+type Calendar[K comparable] struct {
+	keys []K
+	busy map[K]DateRanges
+}
+
+// NewCalendar returns a new empty calendar.
+func NewCalendar[K comparable]() *Calendar[K] {
+	return &Calendar[K]{}
+}
+
+// Keys returns the keys of the calendar in the order they were first added.
+func (c *Calendar[K]) Keys() []K {
+	keys := make([]K, len(c.keys))
+	copy(keys, c.keys)
+	return keys
+}
+
+// Len returns the number of keys in the calendar
+func (c *Calendar[K]) Len() int {
+	return len(c.keys)
+}
+
+// Busy returns the busy dates of the given key.
+// The collection is empty if the key is not in the calendar.
+func (c *Calendar[K]) Busy(key K) DateRanges {
+	drs := c.busy[key]
+	return drs.clone()
+}
+
+// Set replaces the busy dates of the given key, adding the key if needed.
+func (c *Calendar[K]) Set(key K, drs DateRanges) {
+	c.set(key, drs.clone())
+}
+
+// Add marks the dates of the given ranges as busy for the given key,
+// adding the key if needed.
+func (c *Calendar[K]) Add(key K, dataRange ...DateRange) {
+	drs := c.busy[key]
+	drs.Append(dataRange...)
+	c.set(key, drs)
+}
+
+// Remove marks the dates of the given ranges as free for the given key.
+// The key stays in the calendar even if it has no busy dates left.
+func (c *Calendar[K]) Remove(key K, dataRange ...DateRange) {
+	drs, ok := c.busy[key]
+	if !ok {
+		return
+	}
+	drs.Remove(dataRange...)
+	c.busy[key] = drs
+}
+
+// Delete removes the given key and its busy dates from the calendar.
+func (c *Calendar[K]) Delete(key K) {
+	if _, ok := c.busy[key]; !ok {
+		return
+	}
+	delete(c.busy, key)
+	for i, k := range c.keys {
+		if k == key {
+			c.keys = append(c.keys[:i], c.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// FreeOn returns the keys that are free on the given date, in calendar order.
+// It runs in O(k log m) for k keys with at most m busy ranges each.
+func (c *Calendar[K]) FreeOn(date time.Time) []K {
+	date = toDateUTC(date)
+	free := []K{}
+	for _, key := range c.keys {
+		busy := c.busy[key].dr
+		i := firstEndingOnOrAfter(busy, date)
+		if i == len(busy) || date.Before(busy[i].from) {
+			free = append(free, key)
+		}
+	}
+	return free
+}
+
+// FreeDuring returns the keys that are free on every date of the given range,
+// in calendar order. It runs in O(k log m) for k keys with at most m busy ranges each.
+func (c *Calendar[K]) FreeDuring(other DateRange) []K {
+	free := []K{}
+	for _, key := range c.keys {
+		busy := c.busy[key].dr
+		if other.IsEmpty() {
+			free = append(free, key)
+			continue
+		}
+		i := firstEndingOnOrAfter(busy, other.from)
+		if i == len(busy) || other.to.Before(busy[i].from) {
+			free = append(free, key)
+		}
+	}
+	return free
+}
+
+// AllFree returns the dates of the given range when every key is free.
+func (c *Calendar[K]) AllFree(within DateRange) DateRanges {
+	return c.FreeAtLeast(len(c.keys), within)
+}
+
+// FreeAtLeast returns the dates of the given range when at least k keys are free.
+// It returns the whole range if k < 1 and an empty collection if k is larger than
+// the number of keys.
+//
+// The busy dates of all keys are merged in a single sweep with a heap, in
+// O(m log n) for n keys with m busy ranges in total.
+func (c *Calendar[K]) FreeAtLeast(k int, within DateRange) DateRanges {
+	if within.IsEmpty() || k > len(c.keys) {
+		return NewDateRanges()
+	}
+	maxBusy := len(c.keys) - k
+
+	cursors := &cursorHeap{}
+	for _, key := range c.keys {
+		drs := c.busy[key]
+		clipped := drs.Clip(within)
+		if len(clipped.dr) > 0 {
+			heap.Push(cursors, calendarCursor{dr: clipped.dr, date: clipped.dr[0].from})
+		}
+	}
+
+	free := []DateRange{}
+	busy := 0
+	from := within.from
+	for cursors.Len() > 0 {
+		date := (*cursors)[0].date
+		if date.After(from) && busy <= maxBusy {
+			free = append(free, DateRange{from: from, to: date.AddDate(0, 0, -1), nonEmpty: true})
+		}
+		// apply every change of a key between busy and free on this date
+		for cursors.Len() > 0 && (*cursors)[0].date.Equal(date) {
+			cur := &(*cursors)[0]
+			if cur.end {
+				busy--
+				cur.pos++
+				if cur.pos == len(cur.dr) {
+					heap.Pop(cursors)
+					continue
+				}
+				cur.end = false
+				cur.date = cur.dr[cur.pos].from
+			} else {
+				busy++
+				cur.end = true
+				cur.date = cur.dr[cur.pos].to.AddDate(0, 0, 1)
+			}
+			heap.Fix(cursors, 0)
+		}
+		from = date
+	}
+	if !from.After(within.to) {
+		free = append(free, DateRange{from: from, to: within.to, nonEmpty: true})
+	}
+	return NewDateRanges(free...)
+}
+
+// firstEndingOnOrAfter returns the index of the first of the sorted ranges that
+// ends on or after the given date, or len(ranges) if there is none.
+func firstEndingOnOrAfter(ranges []DateRange, date time.Time) int {
+	return sort.Search(len(ranges), func(i int) bool {
+		return !ranges[i].to.Before(date)
+	})
+}
+
+// set stores the busy dates of the given key, adding the key if needed.
+func (c *Calendar[K]) set(key K, drs DateRanges) {
+	if c.busy == nil {
+		c.busy = make(map[K]DateRanges)
+	}
+	if _, ok := c.busy[key]; !ok {
+		c.keys = append(c.keys, key)
+	}
+	c.busy[key] = drs
+}
+
+// calendarCursor walks the busy ranges of a key in a cursorHeap. date is the
+// first date of dr[pos] or, once end is set, the date after it.
+type calendarCursor struct {
+	dr   []DateRange
+	pos  int
+	end  bool
+	date time.Time
+}
+
+// cursorHeap is a min-heap of cursors by date, implementing heap.Interface.
+type cursorHeap []calendarCursor
+
+func (h cursorHeap) Len() int            { return len(h) }
+func (h cursorHeap) Less(i, j int) bool  { return h[i].date.Before(h[j].date) }
+func (h cursorHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *cursorHeap) Push(x interface{}) { *h = append(*h, x.(calendarCursor)) }
+func (h *cursorHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,267 @@
+package daterange_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// staff returns a calendar with the absences of three employees
+func staff() *dr.Calendar[string] {
+	c := dr.NewCalendar[string]()
+	c.Add("ann", dr.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)))
+	c.Add("bob", dr.NewDateRange(time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC)))
+	c.Add("cid")
+	c.Add("cid", dr.NewDateRange(time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)))
+	return c
+}
+
+// test dr.Calendar.Add, Remove, Set, Delete and Keys
+func TestCalendarUpdate(t *testing.T) {
+	c := staff()
+	if got, want := c.Keys(), []string{"ann", "bob", "cid"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+
+	c.Remove("ann", dr.NewDateRange(time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC)))
+	ann := c.Busy("ann")
+	want := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)),
+	}
+	if !reflect.DeepEqual(ann.ToSlice(), want) {
+		t.Errorf("Busy(ann) = %v, want %v", ann, want)
+	}
+
+	// the returned collection is a copy
+	ann.Append(dr.NewDateRange(time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)))
+	if ann = c.Busy("ann"); !reflect.DeepEqual(ann.ToSlice(), want) {
+		t.Errorf("Busy(ann) = %v after changing a copy, want %v", ann, want)
+	}
+
+	c.Remove("dan", dr.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)))
+	c.Delete("bob")
+	c.Set("dan", dr.NewDateRanges())
+	if got, want := c.Keys(), []string{"ann", "cid", "dan"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+	if bob := c.Busy("bob"); bob.Len() != 0 {
+		t.Errorf("Busy(bob) = %v after Delete, want empty", bob)
+	}
+
+	var zero dr.Calendar[int]
+	zero.Add(1, dr.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)))
+	if zero.Len() != 1 {
+		t.Errorf("Len() = %v, want 1", zero.Len())
+	}
+}
+
+// test dr.Calendar.FreeOn
+func TestCalendarFreeOn(t *testing.T) {
+	cal := staff()
+	cases := []struct {
+		name string
+		date time.Time
+		want []string
+	}{
+		{
+			name: "everyone free",
+			date: time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+			want: []string{"ann", "bob", "cid"},
+		},
+		{
+			name: "one absent",
+			date: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			want: []string{"bob", "cid"},
+		},
+		{
+			name: "two absent",
+			date: time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC),
+			want: []string{"ann"},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := cal.FreeOn(c.date)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("FreeOn(%v) = %v, want %v", c.date, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.Calendar.FreeDuring
+func TestCalendarFreeDuring(t *testing.T) {
+	cal := staff()
+	cases := []struct {
+		name  string
+		other dr.DateRange
+		want  []string
+	}{
+		{
+			name:  "zero range",
+			other: dr.DateRange{},
+			want:  []string{"ann", "bob", "cid"},
+		},
+		{
+			name:  "overlapping absences",
+			other: dr.NewDateRange(time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 11, 0, 0, 0, 0, time.UTC)),
+			want:  []string{"cid"},
+		},
+		{
+			name:  "adjacent absences",
+			other: dr.NewDateRange(time.Date(2024, 7, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 11, 0, 0, 0, 0, time.UTC)),
+			want:  []string{"ann", "cid"},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := cal.FreeDuring(c.other)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("FreeDuring(%v) = %v, want %v", c.other, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.Calendar.FreeOn and dr.Calendar.FreeDuring against the busy dates of every key on random calendars
+func TestCalendarFreeRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for round := 0; round < 100; round++ {
+		c := dr.NewCalendar[int]()
+		keys := 1 + rnd.Intn(6)
+		for key := 0; key < keys; key++ {
+			c.Add(key)
+			for i := rnd.Intn(8); i > 0; i-- {
+				from := start.AddDate(0, 0, rnd.Intn(60))
+				c.Add(key, dr.NewDateRange(from, from.AddDate(0, 0, rnd.Intn(6))))
+			}
+			if rnd.Intn(5) == 0 {
+				c.Add(key, dr.NewDateRangeFrom(start.AddDate(0, 0, 50+rnd.Intn(20))))
+			}
+		}
+		from := start.AddDate(0, 0, rnd.Intn(70)-5)
+		other := dr.NewDateRange(from, from.AddDate(0, 0, rnd.Intn(5)))
+		wantOn, wantDuring := []int{}, []int{}
+		for key := 0; key < keys; key++ {
+			busy := c.Busy(key)
+			if !busy.Contains(from) {
+				wantOn = append(wantOn, key)
+			}
+			if !busy.IsAnyDateIn(other) {
+				wantDuring = append(wantDuring, key)
+			}
+		}
+		if got := c.FreeOn(from); !reflect.DeepEqual(got, wantOn) {
+			t.Fatalf("FreeOn(%v) = %v, want %v", from, got, wantOn)
+		}
+		if got := c.FreeDuring(other); !reflect.DeepEqual(got, wantDuring) {
+			t.Fatalf("FreeDuring(%v) = %v, want %v", other, got, wantDuring)
+		}
+	}
+}
+
+// test dr.Calendar.AllFree and dr.Calendar.FreeAtLeast
+func TestCalendarFreeAtLeast(t *testing.T) {
+	cal := staff()
+	july := dr.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC))
+	cases := []struct {
+		name   string
+		k      int
+		within dr.DateRange
+		want   []dr.DateRange
+	}{
+		{
+			name:   "zero range",
+			k:      1,
+			within: dr.DateRange{},
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "k < 1",
+			k:      0,
+			within: july,
+			want:   []dr.DateRange{july},
+		},
+		{
+			name:   "more than the keys",
+			k:      4,
+			within: july,
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "everyone",
+			k:      3,
+			within: july,
+			want:   []dr.DateRange{dr.NewDateRange(time.Date(2024, 7, 16, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:   "at least two",
+			k:      2,
+			within: july,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 7, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 11, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:   "at least one, clipped",
+			k:      1,
+			within: dr.NewDateRange(time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC)),
+			want:   []dr.DateRange{dr.NewDateRange(time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC))},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := cal.FreeAtLeast(c.k, c.within)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("FreeAtLeast(%v, %v) = %v, want %v", c.k, c.within, got, c.want)
+			}
+		})
+	}
+
+	all := cal.AllFree(july)
+	if want := []dr.DateRange{dr.NewDateRange(time.Date(2024, 7, 16, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC))}; !reflect.DeepEqual(all.ToSlice(), want) {
+		t.Errorf("AllFree(%v) = %v, want %v", july, all, want)
+	}
+}
+
+// test dr.Calendar.FreeAtLeast against counting every date on random calendars
+func TestCalendarFreeAtLeastRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for round := 0; round < 100; round++ {
+		c := dr.NewCalendar[int]()
+		keys := 1 + rnd.Intn(6)
+		for key := 0; key < keys; key++ {
+			c.Add(key)
+			for i := rnd.Intn(5); i > 0; i-- {
+				from := start.AddDate(0, 0, rnd.Intn(40))
+				c.Add(key, dr.NewDateRange(from, from.AddDate(0, 0, rnd.Intn(6))))
+			}
+		}
+		from := start.AddDate(0, 0, rnd.Intn(20))
+		within := dr.NewDateRange(from, from.AddDate(0, 0, rnd.Intn(30)))
+		for k := 0; k <= keys+1; k++ {
+			dates := []time.Time{}
+			for date := within.From(); !date.After(within.To()); date = date.AddDate(0, 0, 1) {
+				if len(c.FreeOn(date)) >= k {
+					dates = append(dates, date)
+				}
+			}
+			want := dr.FromDates(dates...)
+			got := c.FreeAtLeast(k, within)
+			if !got.Equal(want) {
+				t.Fatalf("FreeAtLeast(%v, %v) = %v, want %v", k, within, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package daterange
+
+import "container/heap"
+
+// Conflict is a pair of overlapping ranges found by Conflicts.
+type Conflict struct {
+	I, J    int       // indices of the overlapping ranges, I < J
+	Overlap DateRange // dates shared by both ranges
+}
+
+// Conflicts returns every pair of the given ranges that overlap, as defined by
+// DateRange.Overlaps, with the dates they share. Unlike NewDateRanges the ranges
+// are not merged, so overlapping bookings can be reported.
+//
+// The conflicts are returned in the order the sweep finds them, that is by the
+// first date of the range of each pair starting last. It runs in O(n log n + k)
+// for n ranges and k conflicts.
+func Conflicts(ranges []DateRange) []Conflict {
+	return ConflictsFunc(ranges, func(dr DateRange) DateRange {
+		return dr
+	})
+}
+
+// ConflictsFunc is like Conflicts for a slice of records, such as bookings,
+// using rangeOf to get the DateRange of every record.
+func ConflictsFunc[T any](items []T, rangeOf func(T) DateRange) []Conflict {
+	ranges := make([]DateRange, len(items))
+	for i, item := range items {
+		ranges[i] = rangeOf(item)
+	}
+	order := sortedIndices(ranges, func(a, b DateRange) bool {
+		return a.from.Before(b.from)
+	})
+
+	conflicts := []Conflict{}
+	active := &endHeap{} // ranges started and not yet ended, by last date
+	for _, j := range order {
+		for active.Len() > 0 && (*active)[0].end.Before(ranges[j].from) {
+			heap.Pop(active)
+		}
+		// every active range starts before and ends after the start of this one
+		for _, item := range *active {
+			i := item.index
+			c := Conflict{I: i, J: j, Overlap: ranges[i].Intersection(ranges[j])}
+			if i > j {
+				c.I, c.J = j, i
+			}
+			conflicts = append(conflicts, c)
+		}
+		heap.Push(active, endItem{end: ranges[j].to, index: j})
+	}
+	return conflicts
+}
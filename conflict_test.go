@@ -0,0 +1,121 @@
+package daterange_test
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// test dr.Conflicts
+func TestConflicts(t *testing.T) {
+	cases := []struct {
+		name   string
+		ranges []dr.DateRange
+		want   []dr.Conflict
+	}{
+		{
+			name:   "empty",
+			ranges: []dr.DateRange{},
+			want:   []dr.Conflict{},
+		},
+		{
+			name: "adjacent and zero ranges do not conflict",
+			ranges: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+				{},
+				dr.NewDateRange(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []dr.Conflict{},
+		},
+		{
+			name: "overlapping bookings",
+			ranges: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []dr.Conflict{
+				{I: 0, J: 1, Overlap: dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC))},
+				{I: 1, J: 2, Overlap: dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))},
+			},
+		},
+		{
+			name: "identical ranges",
+			ranges: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []dr.Conflict{
+				{I: 0, J: 1, Overlap: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := dr.Conflicts(c.ranges)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Conflicts(%v) = %v, want %v", c.ranges, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.ConflictsFunc with records
+func TestConflictsFunc(t *testing.T) {
+	type booking struct {
+		guest string
+		stay  dr.DateRange
+	}
+	bookings := []booking{
+		{guest: "Ann", stay: dr.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 7, 0, 0, 0, 0, time.UTC))},
+		{guest: "Bob", stay: dr.NewDateRange(time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 9, 0, 0, 0, 0, time.UTC))},
+		{guest: "Cid", stay: dr.NewDateRange(time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC))},
+	}
+	got := dr.ConflictsFunc(bookings, func(b booking) dr.DateRange {
+		return b.stay
+	})
+	want := []dr.Conflict{
+		{I: 0, J: 2, Overlap: dr.NewDateRange(time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 7, 0, 0, 0, 0, time.UTC))},
+		{I: 1, J: 2, Overlap: dr.NewDateRange(time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC))},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ConflictsFunc() = %v, want %v", got, want)
+	}
+}
+
+// test dr.Conflicts against all pairs on random ranges
+func TestConflictsRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for round := 0; round < 100; round++ {
+		ranges := make([]dr.DateRange, rnd.Intn(30))
+		for i := range ranges {
+			from := start.AddDate(0, 0, rnd.Intn(60))
+			ranges[i] = dr.NewDateRange(from, from.AddDate(0, 0, rnd.Intn(10)))
+		}
+		want := []dr.Conflict{}
+		for i := range ranges {
+			for j := i + 1; j < len(ranges); j++ {
+				if ranges[i].Overlaps(ranges[j]) {
+					want = append(want, dr.Conflict{I: i, J: j, Overlap: ranges[i].Intersection(ranges[j])})
+				}
+			}
+		}
+		got := dr.Conflicts(ranges)
+		sort.Slice(got, func(a, b int) bool {
+			if got[a].I != got[b].I {
+				return got[a].I < got[b].I
+			}
+			return got[a].J < got[b].J
+		})
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("Conflicts(%v) = %v, want %v", ranges, got, want)
+		}
+	}
+}
@@ -27,26 +27,47 @@ package daterange
 
 import (
 	"fmt"
+	"math"
 	"time"
 )
 
 // DateRange is an **inclusive** range of dates. The range is defined by two dates.
+// Either end can be unbounded, see NewDateRangeFrom and NewDateRangeUntil.
+//
+// The zero value DateRange{} is the empty range, which has no dates. It is
+// distinct from the range of the single date 0001-01-01, the zero time.Time,
+// which is a real date. Use IsEmpty to check for the empty range.
 type DateRange struct {
-	from time.Time // inclusive dates
-	to   time.Time
+	from     time.Time // inclusive dates
+	to       time.Time
+	nonEmpty bool // false only for the empty range
 }
 
 // NewDateRange returns a new DateRange from the given dates. This automatically
 // order input dates and truncates the time portion of the dates, ignoring the
 // time zone (for example 2024-01-26 9pm EST will still be the 26th of January 2024).
 // Use MustNewDateRange if you want to panic if `from` date if after the `to`date .
+//
+// Finite dates beyond year -999999 or year 999999 are clamped to the days next
+// to the infinite bounds. The From and To dates of an unbounded end give the
+// same range as NewDateRangeFrom, NewDateRangeUntil or NewUnboundedDateRange.
 // Note: Only the date portion of the time.Time values is compared. The time portion is ignored.
 func NewDateRange(from, to time.Time) DateRange {
 	from = toDateUTC(from)
 	to = toDateUTC(to)
+	from, to = minTime(from, to), maxTime(from, to)
+	switch {
+	case isNegInf(from) && isPosInf(to):
+		return NewUnboundedDateRange()
+	case isNegInf(from):
+		return NewDateRangeUntil(to)
+	case isPosInf(to):
+		return NewDateRangeFrom(from)
+	}
 	return DateRange{
-		from: minTime(from, to),
-		to:   maxTime(from, to),
+		from:     from,
+		to:       to,
+		nonEmpty: true,
 	}
 }
 
@@ -65,37 +86,127 @@ func MustNewDateRange(from, to time.Time) DateRange {
 	return NewDateRange(from, to)
 }
 
+// NewDateRangeFrom returns a new DateRange of all the dates from the given date on,
+// with no end, for example a contract without an end date.
+// Note: Only the date portion of the time.Time value is used. The time portion is ignored.
+func NewDateRangeFrom(from time.Time) DateRange {
+	return DateRange{
+		from:     minTime(toDateUTC(from), lastDate),
+		to:       posInf,
+		nonEmpty: true,
+	}
+}
+
+// NewDateRangeUntil returns a new DateRange of all the dates up to the given date,
+// with no start, for example a policy that applies since forever.
+// Note: Only the date portion of the time.Time value is used. The time portion is ignored.
+func NewDateRangeUntil(to time.Time) DateRange {
+	return DateRange{
+		from:     negInf,
+		to:       maxTime(toDateUTC(to), firstDate),
+		nonEmpty: true,
+	}
+}
+
+// NewUnboundedDateRange returns a new DateRange of all the dates, with no start and no end.
+func NewUnboundedDateRange() DateRange {
+	return DateRange{
+		from:     negInf,
+		to:       posInf,
+		nonEmpty: true,
+	}
+}
+
+// NewPeriod returns the calendar period of the given Granularity containing the
+// given date, for example the whole month or the whole ISO week of the date.
+// An empty DateRange is returned for an unknown Granularity.
+// Note: Only the date portion of the time.Time value is used. The time portion is ignored.
+func NewPeriod(date time.Time, g Granularity) DateRange {
+	if !g.valid() {
+		return DateRange{}
+	}
+	from := g.periodStart(toDateUTC(date))
+	return DateRange{
+		from:     toDateUTC(from),
+		to:       toDateUTC(g.nextPeriod(from).AddDate(0, 0, -1)),
+		nonEmpty: true,
+	}
+}
+
 // From returns the start date of the range, as midnight of that day, UTC time.
+// For a range with no start it returns a date before every other date; use
+// IsFromInf to check for it.
 func (d DateRange) From() time.Time {
 	return d.from
 }
 
 // To returns the end date of the range, as midnight of that day, UTC time.
+// For a range with no end it returns a date after every other date; use
+// IsToInf to check for it.
 func (d DateRange) To() time.Time {
 	return d.to
 }
 
-// String returns a string representation of the DateRange
+// String returns a string representation of the DateRange.
+// Unbounded ends are written as -inf and +inf and the empty range as {}.
 func (d DateRange) String() string {
-	return "{" + d.from.Format("2006-01-02") + " - " + d.to.Format("2006-01-02") + "}"
+	if d.IsEmpty() {
+		return "{}"
+	}
+	return "{" + formatBound(d.from) + " - " + formatBound(d.to) + "}"
+}
+
+// Days returns the number of dates in the range, including both ends.
+// It returns 0 for the empty range and math.MaxInt for an unbounded range.
+func (d DateRange) Days() int {
+	if d.IsEmpty() {
+		return 0
+	}
+	if !d.IsBounded() {
+		return math.MaxInt
+	}
+	return daysBetween(d.from, d.to) + 1
+}
+
+// IsEmpty returns true if the range is the empty range, that has no dates.
+func (d DateRange) IsEmpty() bool {
+	return !d.nonEmpty
 }
 
-// IsZero returns true if the both dates of range are zero
+// IsZero returns true if the range is the zero value DateRange{}, that is the
+// empty range. It is the same as IsEmpty, which new code should prefer.
+// Note: a range of the single date 0001-01-01 is not zero, see DateRange.
 func (d DateRange) IsZero() bool {
-	return d.from.IsZero() && d.to.IsZero()
+	return d.IsEmpty()
+}
+
+// IsFromInf returns true if the range has no start.
+func (d DateRange) IsFromInf() bool {
+	return isNegInf(d.from)
+}
+
+// IsToInf returns true if the range has no end.
+func (d DateRange) IsToInf() bool {
+	return isPosInf(d.to)
+}
+
+// IsBounded returns true if the range has both a start and an end.
+func (d DateRange) IsBounded() bool {
+	return !d.IsFromInf() && !d.IsToInf()
 }
 
 // Contains returns true if the given date is in the range. The range is inclusive.
+// An unbounded end contains every date on its side.
 func (d DateRange) Contains(date time.Time) bool {
-	if d.IsZero() {
+	if d.IsEmpty() {
 		return false
 	}
-	return !date.Before(d.from) && !date.After(d.to)
+	return (d.IsFromInf() || !date.Before(d.from)) && (d.IsToInf() || !date.After(d.to))
 }
 
 // Overlaps returns true if the given range overlaps with the range. The range is inclusive.
 func (d DateRange) Overlaps(other DateRange) bool {
-	if d.IsZero() || other.IsZero() {
+	if d.IsEmpty() || other.IsEmpty() {
 		return false
 	}
 	return d.Contains(other.from) || d.Contains(other.to) || other.Contains(d.from) || other.Contains(d.to)
@@ -103,18 +214,20 @@ func (d DateRange) Overlaps(other DateRange) bool {
 
 // Includes returns true if the given range is included in the range. The range is inclusive.
 func (d DateRange) Includes(other DateRange) bool {
-	if d.IsZero() || other.IsZero() {
+	if d.IsEmpty() || other.IsEmpty() {
 		return false
 	}
 	return d.Contains(other.from) && d.Contains(other.to)
 }
 
-// Intersection returns the intersection of the two DateRanges
+// Intersection returns the intersection of the two DateRanges.
+// The empty range is returned if they do not overlap.
 func (d DateRange) Intersection(other DateRange) DateRange {
 	if d.Overlaps(other) {
 		return DateRange{
-			from: maxTime(d.from, other.from),
-			to:   minTime(d.to, other.to),
+			from:     maxTime(d.from, other.from),
+			to:       minTime(d.to, other.to),
+			nonEmpty: true,
 		}
 	}
 	return DateRange{}
@@ -123,43 +236,46 @@ func (d DateRange) Intersection(other DateRange) DateRange {
 // Union returns a DateRanges collection that is the union of the two DateRanges
 func (d DateRange) Union(other DateRange) DateRanges {
 	switch {
-	case d.IsZero() && other.IsZero():
+	case d.IsEmpty() && other.IsEmpty():
 		return NewDateRanges()
-	case d.IsZero():
+	case d.IsEmpty():
 		return NewDateRanges(other)
-	case other.IsZero():
+	case other.IsEmpty():
 		return NewDateRanges(d)
 	}
 
-	// non zero, check for overlapping
+	// non empty, check for overlapping
 	if d.Overlaps(other) {
 		return NewDateRanges(
 			DateRange{
-				from: minTime(d.from, other.from),
-				to:   maxTime(d.to, other.to),
+				from:     minTime(d.from, other.from),
+				to:       maxTime(d.to, other.to),
+				nonEmpty: true,
 			},
 		)
 	}
 
-	// non zero, no overlapping, check for adjacent
+	// non empty, no overlapping, check for adjacent
 	if d.to.AddDate(0, 0, 1).Equal(other.from) {
 		return NewDateRanges(
 			DateRange{
-				from: d.from,
-				to:   other.to,
+				from:     d.from,
+				to:       other.to,
+				nonEmpty: true,
 			},
 		)
 	}
 	if other.to.AddDate(0, 0, 1).Equal(d.from) {
 		return NewDateRanges(
 			DateRange{
-				from: other.from,
-				to:   d.to,
+				from:     other.from,
+				to:       d.to,
+				nonEmpty: true,
 			},
 		)
 	}
 
-	// non zero, no overlapping, disjoint ranges, return in ascending order
+	// non empty, no overlapping, disjoint ranges, return in ascending order
 	if d.from.Before(other.from) {
 		return NewDateRanges(
 			d,
@@ -175,7 +291,7 @@ func (d DateRange) Union(other DateRange) DateRanges {
 
 // Difference returns a DateRanges collection that is the difference of the two DateRanges
 func (d DateRange) Difference(other DateRange) DateRanges {
-	if d.IsZero() {
+	if d.IsEmpty() {
 		return NewDateRanges()
 	}
 
@@ -186,15 +302,99 @@ func (d DateRange) Difference(other DateRange) DateRanges {
 	ranges := NewDateRanges()
 	if other.from.After(d.from) {
 		ranges.Append(DateRange{
-			from: d.from,
-			to:   other.from.AddDate(0, 0, -1),
+			from:     d.from,
+			to:       other.from.AddDate(0, 0, -1),
+			nonEmpty: true,
 		})
 	}
 	if other.to.Before(d.to) {
 		ranges.Append(DateRange{
-			from: other.to.AddDate(0, 0, 1),
-			to:   d.to,
+			from:     other.to.AddDate(0, 0, 1),
+			to:       d.to,
+			nonEmpty: true,
 		})
 	}
 	return ranges
 }
+
+// SplitBy splits the range at the boundaries of the given calendar period.
+// The returned ranges are ordered; the first and last ones may be partial periods.
+// An empty slice is returned for an empty or unbounded range or an unknown Granularity.
+func (d DateRange) SplitBy(g Granularity) []DateRange {
+	ranges := []DateRange{}
+	if d.IsEmpty() || !d.IsBounded() || !g.valid() {
+		return ranges
+	}
+	for from := d.from; !from.After(d.to); {
+		next := g.nextPeriod(g.periodStart(from))
+		ranges = append(ranges, DateRange{
+			from:     from,
+			to:       minTime(next.AddDate(0, 0, -1), d.to),
+			nonEmpty: true,
+		})
+		from = next
+	}
+	return ranges
+}
+
+// SplitEvery splits the range into consecutive chunks of n days, starting
+// with the first date of the range. The last chunk may be shorter.
+// An empty slice is returned for an empty or unbounded range or if n is less than 1.
+func (d DateRange) SplitEvery(n int) []DateRange {
+	ranges := []DateRange{}
+	if d.IsEmpty() || !d.IsBounded() || n < 1 {
+		return ranges
+	}
+	// a chunk longer than the range is the whole range, clamp n to avoid overflows
+	if days := d.Days(); n > days {
+		n = days
+	}
+	for from := d.from; !from.After(d.to); from = from.AddDate(0, 0, n) {
+		ranges = append(ranges, DateRange{
+			from:     from,
+			to:       minTime(from.AddDate(0, 0, n-1), d.to),
+			nonEmpty: true,
+		})
+	}
+	return ranges
+}
+
+// AlignOutward extends the range to whole calendar periods of the given Granularity,
+// covering every date of the range.
+// An empty DateRange is returned for an empty range or an unknown Granularity.
+func (d DateRange) AlignOutward(g Granularity) DateRange {
+	if d.IsEmpty() || !g.valid() {
+		return DateRange{}
+	}
+	return DateRange{
+		from:     NewPeriod(d.from, g).from,
+		to:       NewPeriod(d.to, g).to,
+		nonEmpty: true,
+	}
+}
+
+// AlignInward shrinks the range to the whole calendar periods of the given
+// Granularity that it fully contains.
+// An empty DateRange is returned if no complete period fits in the range,
+// for an empty range or for an unknown Granularity.
+func (d DateRange) AlignInward(g Granularity) DateRange {
+	if d.IsEmpty() || !g.valid() {
+		return DateRange{}
+	}
+	from := NewPeriod(d.from, g)
+	if !from.from.Equal(d.from) {
+		from = NewPeriod(from.to.AddDate(0, 0, 1), g)
+	}
+	to := NewPeriod(d.to, g)
+	if !to.to.Equal(d.to) {
+		to = NewPeriod(to.from.AddDate(0, 0, -1), g)
+	}
+	if from.from.After(to.to) {
+		return DateRange{}
+	}
+	return DateRange{
+		from:     from.from,
+		to:       to.to,
+		nonEmpty: true,
+	}
+}
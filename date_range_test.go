@@ -1,6 +1,7 @@
 package daterange_test
 
 import (
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -8,6 +9,11 @@ import (
 	dr "github.com/felixenescu/date-range"
 )
 
+// datePtr returns a pointer to the date, for the optional date fields.
+func datePtr(date time.Time) *time.Time {
+	return &date
+}
+
 // test dr.DateRange.NewDateRange
 func TestNewDateRange(t *testing.T) {
 	cases := []struct {
@@ -118,7 +124,7 @@ func TestMustNewDateRange(t *testing.T) {
 			name: "zero zero",
 			from: time.Time{},
 			to:   time.Time{},
-			want: dr.DateRange{},
+			want: dr.NewDateRange(time.Time{}, time.Time{}),
 		},
 		{
 			name: "zero non zero",
@@ -239,8 +245,13 @@ func TestDateRangeString(t *testing.T) {
 		want string
 	}{
 		{
-			name: "zero",
+			name: "empty",
 			d:    dr.DateRange{},
+			want: "{}",
+		},
+		{
+			name: "first date",
+			d:    dr.NewDateRange(time.Time{}, time.Time{}),
 			want: "{0001-01-01 - 0001-01-01}",
 		},
 		{
@@ -248,6 +259,21 @@ func TestDateRangeString(t *testing.T) {
 			d:    dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)),
 			want: "{2019-01-01 - 2019-01-02}",
 		},
+		{
+			name: "no end",
+			d:    dr.NewDateRangeFrom(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)),
+			want: "{2019-01-01 - +inf}",
+		},
+		{
+			name: "no start",
+			d:    dr.NewDateRangeUntil(time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)),
+			want: "{-inf - 2019-01-02}",
+		},
+		{
+			name: "unbounded",
+			d:    dr.NewUnboundedDateRange(),
+			want: "{-inf - +inf}",
+		},
 	}
 	for _, c := range cases {
 		t.Logf("Running test %s", c.name)
@@ -259,7 +285,7 @@ func TestDateRangeString(t *testing.T) {
 	}
 }
 
-// test dr.DateRange.IsZero
+// test dr.DateRange.IsZero and dr.DateRange.IsEmpty
 func TestDateRangeIsZero(t *testing.T) {
 	cases := []struct {
 		name string
@@ -272,9 +298,9 @@ func TestDateRangeIsZero(t *testing.T) {
 			want: true,
 		},
 		{
-			name: "zero-initialized",
+			name: "first date",
 			d:    dr.NewDateRange(time.Time{}, time.Time{}),
-			want: true,
+			want: false,
 		},
 		{
 			name: "non-zero",
@@ -288,6 +314,9 @@ func TestDateRangeIsZero(t *testing.T) {
 			if got := c.d.IsZero(); got != c.want {
 				t.Errorf("DateRange.IsZero() = %v, want %v", got, c.want)
 			}
+			if got := c.d.IsEmpty(); got != c.want {
+				t.Errorf("DateRange.IsEmpty() = %v, want %v", got, c.want)
+			}
 		})
 	}
 }
@@ -312,6 +341,12 @@ func TestDateRangeContains(t *testing.T) {
 			t:    time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
 			want: false,
 		},
+		{
+			name: "first date range, zero time",
+			d:    dr.NewDateRange(time.Time{}, time.Time{}),
+			t:    time.Time{},
+			want: true,
+		},
 		{
 			name: "non zero range, non zero time, before from",
 			d:    dr.NewDateRange(time.Date(2019, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC)),
@@ -537,6 +572,18 @@ func TestDateRangeIntersection(t *testing.T) {
 			o:    dr.NewDateRange(time.Date(2019, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 14, 0, 0, 0, 0, time.UTC)),
 			want: dr.DateRange{},
 		},
+		{
+			name: "first date range, first date other",
+			d:    dr.NewDateRange(time.Time{}, time.Time{}),
+			o:    dr.NewDateRange(time.Time{}, time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)),
+			want: dr.NewDateRange(time.Time{}, time.Time{}),
+		},
+		{
+			name: "first date range, non zero other",
+			d:    dr.NewDateRange(time.Time{}, time.Time{}),
+			o:    dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC)),
+			want: dr.DateRange{},
+		},
 		{
 			name: "non zero range, non zero other, outside after",
 			d:    dr.NewDateRange(time.Date(2019, 1, 16, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 20, 0, 0, 0, 0, time.UTC)),
@@ -808,3 +855,625 @@ func TestDateRangeDifference(t *testing.T) {
 		})
 	}
 }
+
+// test dr.DateRange.SplitBy
+func TestDateRangeSplitBy(t *testing.T) {
+	cases := []struct {
+		name string
+		d    dr.DateRange
+		g    dr.Granularity
+		want []dr.DateRange
+	}{
+		{
+			name: "zero range",
+			d:    dr.DateRange{},
+			g:    dr.Month,
+			want: []dr.DateRange{},
+		},
+		{
+			name: "unknown granularity",
+			d:    dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+			g:    dr.Granularity(0),
+			want: []dr.DateRange{},
+		},
+		{
+			name: "day",
+			d:    dr.NewDateRange(time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+			g:    dr.Day,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "iso week",
+			d:    dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)),
+			g:    dr.ISOWeek,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "week starting sunday",
+			d:    dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)),
+			g:    dr.WeekStarting(time.Sunday),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "week starting saturday single day",
+			d:    dr.NewDateRange(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)),
+			g:    dr.WeekStarting(time.Saturday),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "month",
+			d:    dr.NewDateRange(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)),
+			g:    dr.Month,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "month inside one month",
+			d:    dr.NewDateRange(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+			g:    dr.Month,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "quarter",
+			d:    dr.NewDateRange(time.Date(2023, 11, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)),
+			g:    dr.Quarter,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2023, 11, 15, 0, 0, 0, 0, time.UTC), time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "year",
+			d:    dr.NewDateRange(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)),
+			g:    dr.Year,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := c.d.SplitBy(c.g)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("%v.SplitBy(%v) = %v, want %v", c.d, c.g, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRange.SplitEvery
+func TestDateRangeSplitEvery(t *testing.T) {
+	cases := []struct {
+		name string
+		d    dr.DateRange
+		n    int
+		want []dr.DateRange
+	}{
+		{
+			name: "zero range",
+			d:    dr.DateRange{},
+			n:    7,
+			want: []dr.DateRange{},
+		},
+		{
+			name: "huge n",
+			d:    dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+			n:    math.MaxInt,
+			want: []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name: "half of the largest n",
+			d:    dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+			n:    math.MaxInt / 2,
+			want: []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name: "invalid n",
+			d:    dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+			n:    0,
+			want: []dr.DateRange{},
+		},
+		{
+			name: "exact chunks",
+			d:    dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)),
+			n:    3,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "partial last chunk",
+			d:    dr.NewDateRange(time.Date(2024, 2, 25, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)),
+			n:    4,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 2, 25, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "chunk larger than range",
+			d:    dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+			n:    30,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := c.d.SplitEvery(c.n)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("%v.SplitEvery(%v) = %v, want %v", c.d, c.n, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.NewPeriod
+func TestNewPeriod(t *testing.T) {
+	cases := []struct {
+		name string
+		date time.Time
+		g    dr.Granularity
+		want dr.DateRange
+	}{
+		{
+			name: "unknown granularity",
+			date: time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC),
+			g:    dr.Granularity(100),
+			want: dr.DateRange{},
+		},
+		{
+			name: "day",
+			date: time.Date(2024, 2, 14, 21, 0, 0, 0, time.FixedZone("EST", -5*60*60)),
+			g:    dr.Day,
+			want: dr.NewDateRange(time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "iso week",
+			date: time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC),
+			g:    dr.ISOWeek,
+			want: dr.NewDateRange(time.Date(2024, 2, 12, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 18, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "week starting sunday on a sunday",
+			date: time.Date(2024, 2, 18, 0, 0, 0, 0, time.UTC),
+			g:    dr.WeekStarting(time.Sunday),
+			want: dr.NewDateRange(time.Date(2024, 2, 18, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 24, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "month",
+			date: time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC),
+			g:    dr.Month,
+			want: dr.NewDateRange(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "quarter",
+			date: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+			g:    dr.Quarter,
+			want: dr.NewDateRange(time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "year",
+			date: time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC),
+			g:    dr.Year,
+			want: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := dr.NewPeriod(c.date, c.g)
+			if got != c.want {
+				t.Errorf("NewPeriod(%v, %v) = %v, want %v", c.date, c.g, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRange.AlignOutward
+func TestDateRangeAlignOutward(t *testing.T) {
+	cases := []struct {
+		name string
+		d    dr.DateRange
+		g    dr.Granularity
+		want dr.DateRange
+	}{
+		{
+			name: "zero range",
+			d:    dr.DateRange{},
+			g:    dr.Month,
+			want: dr.DateRange{},
+		},
+		{
+			name: "iso week",
+			d:    dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)),
+			g:    dr.ISOWeek,
+			want: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "month already aligned",
+			d:    dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+			g:    dr.Month,
+			want: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "quarter",
+			d:    dr.NewDateRange(time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)),
+			g:    dr.Quarter,
+			want: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := c.d.AlignOutward(c.g)
+			if got != c.want {
+				t.Errorf("%v.AlignOutward(%v) = %v, want %v", c.d, c.g, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRange.AlignInward
+func TestDateRangeAlignInward(t *testing.T) {
+	cases := []struct {
+		name string
+		d    dr.DateRange
+		g    dr.Granularity
+		want dr.DateRange
+	}{
+		{
+			name: "zero range",
+			d:    dr.DateRange{},
+			g:    dr.Month,
+			want: dr.DateRange{},
+		},
+		{
+			name: "iso week",
+			d:    dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 23, 0, 0, 0, 0, time.UTC)),
+			g:    dr.ISOWeek,
+			want: dr.NewDateRange(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "month already aligned",
+			d:    dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+			g:    dr.Month,
+			want: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "month aligned start only",
+			d:    dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 30, 0, 0, 0, 0, time.UTC)),
+			g:    dr.Month,
+			want: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "no complete month",
+			d:    dr.NewDateRange(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)),
+			g:    dr.Month,
+			want: dr.DateRange{},
+		},
+		{
+			name: "no complete week inside one week",
+			d:    dr.NewDateRange(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)),
+			g:    dr.ISOWeek,
+			want: dr.DateRange{},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := c.d.AlignInward(c.g)
+			if got != c.want {
+				t.Errorf("%v.AlignInward(%v) = %v, want %v", c.d, c.g, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRange.Days
+func TestDateRangeDays(t *testing.T) {
+	cases := []struct {
+		name string
+		d    dr.DateRange
+		want int
+	}{
+		{
+			name: "zero range",
+			d:    dr.DateRange{},
+			want: 0,
+		},
+		{
+			name: "first date",
+			d:    dr.NewDateRange(time.Time{}, time.Time{}),
+			want: 1,
+		},
+		{
+			name: "one day",
+			d:    dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			want: 1,
+		},
+		{
+			name: "leap february",
+			d:    dr.NewDateRange(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+			want: 29,
+		},
+		{
+			name: "across dst change",
+			d:    dr.NewDateRange(time.Date(2024, 3, 30, 23, 0, 0, 0, time.FixedZone("EET", 2*60*60)), time.Date(2024, 4, 1, 1, 0, 0, 0, time.FixedZone("EEST", 3*60*60))),
+			want: 3,
+		},
+		{
+			name: "more than 292 years",
+			d:    dr.NewDateRange(time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(1999, 12, 31, 0, 0, 0, 0, time.UTC)),
+			want: 146097,
+		},
+		{
+			name: "unbounded",
+			d:    dr.NewDateRangeFrom(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			want: math.MaxInt,
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.d.Days(); got != c.want {
+				t.Errorf("%v.Days() = %v, want %v", c.d, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.NewDateRangeFrom, dr.NewDateRangeUntil and dr.NewUnboundedDateRange
+func TestUnboundedDateRange(t *testing.T) {
+	cases := []struct {
+		name          string
+		d             dr.DateRange
+		wantFromInf   bool
+		wantToInf     bool
+		wantFrom      time.Time
+		wantTo        time.Time
+		wantIsZero    bool
+		wantIsBounded bool
+	}{
+		{
+			name:          "bounded",
+			d:             dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			wantFrom:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantTo:        time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			wantIsBounded: true,
+		},
+		{
+			name:      "no end",
+			d:         dr.NewDateRangeFrom(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)),
+			wantToInf: true,
+			wantFrom:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "no start",
+			d:           dr.NewDateRangeUntil(time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)),
+			wantFromInf: true,
+			wantTo:      time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "no start from the zero date",
+			d:           dr.NewDateRangeUntil(time.Time{}),
+			wantFromInf: true,
+			wantTo:      time.Time{},
+		},
+		{
+			name:        "unbounded",
+			d:           dr.NewUnboundedDateRange(),
+			wantFromInf: true,
+			wantToInf:   true,
+		},
+		{
+			name:        "from the infinite bounds",
+			d:           dr.NewDateRange(dr.NewUnboundedDateRange().From(), dr.NewUnboundedDateRange().To()),
+			wantFromInf: true,
+			wantToInf:   true,
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.d.IsFromInf(); got != c.wantFromInf {
+				t.Errorf("%v.IsFromInf() = %v, want %v", c.d, got, c.wantFromInf)
+			}
+			if got := c.d.IsToInf(); got != c.wantToInf {
+				t.Errorf("%v.IsToInf() = %v, want %v", c.d, got, c.wantToInf)
+			}
+			if !c.wantFromInf && !c.d.From().Equal(c.wantFrom) {
+				t.Errorf("%v.From() = %v, want %v", c.d, c.d.From(), c.wantFrom)
+			}
+			if !c.wantToInf && !c.d.To().Equal(c.wantTo) {
+				t.Errorf("%v.To() = %v, want %v", c.d, c.d.To(), c.wantTo)
+			}
+			if got := c.d.IsZero(); got != c.wantIsZero {
+				t.Errorf("%v.IsZero() = %v, want %v", c.d, got, c.wantIsZero)
+			}
+			if got := c.d.IsBounded(); got != c.wantIsBounded {
+				t.Errorf("%v.IsBounded() = %v, want %v", c.d, got, c.wantIsBounded)
+			}
+		})
+	}
+}
+
+// test dr.NewDateRange at and beyond the infinite bounds
+func TestNewDateRangeInfiniteBounds(t *testing.T) {
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	unbounded := dr.NewUnboundedDateRange()
+	cases := []struct {
+		name string
+		d    dr.DateRange
+		want dr.DateRange
+	}{
+		{
+			name: "no end",
+			d:    dr.NewDateRange(date, unbounded.To()),
+			want: dr.NewDateRangeFrom(date),
+		},
+		{
+			name: "no start",
+			d:    dr.NewDateRange(unbounded.From(), date),
+			want: dr.NewDateRangeUntil(date),
+		},
+		{
+			name: "only the positive bound",
+			d:    dr.NewDateRange(unbounded.To(), unbounded.To()),
+			want: dr.NewDateRangeFrom(unbounded.To()),
+		},
+		{
+			name: "only the negative bound",
+			d:    dr.NewDateRange(unbounded.From(), unbounded.From()),
+			want: dr.NewDateRangeUntil(unbounded.From()),
+		},
+		{
+			name: "last finite year",
+			d:    dr.NewDateRange(time.Date(999999, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(999999, 12, 31, 0, 0, 0, 0, time.UTC)),
+			want: dr.MustNewDateRange(time.Date(999999, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(999999, 12, 31, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			if c.d != c.want {
+				t.Errorf("got %v, want %v", c.d, c.want)
+			}
+		})
+	}
+	if d := cases[len(cases)-1].d; !d.IsBounded() || d.Days() != 1 {
+		t.Errorf("%v.IsBounded() = %v, Days() = %v, want true, 1", d, d.IsBounded(), d.Days())
+	}
+
+	first := time.Date(-1000000, 1, 2, 0, 0, 0, 0, time.UTC)
+	last := time.Date(1000000, 12, 30, 0, 0, 0, 0, time.UTC)
+	beyond := []struct {
+		name string
+		d    dr.DateRange
+		want dr.DateRange
+	}{
+		{
+			name: "before the first finite date",
+			d:    dr.NewDateRange(time.Date(-2000000, 1, 1, 0, 0, 0, 0, time.UTC), date),
+			want: dr.MustNewDateRange(first, date),
+		},
+		{
+			name: "after the last finite date",
+			d:    dr.NewDateRange(date, time.Date(2000000, 1, 1, 0, 0, 0, 0, time.UTC)),
+			want: dr.MustNewDateRange(date, last),
+		},
+		{
+			name: "must, after the last finite date",
+			d:    dr.MustNewDateRange(date, time.Date(3000000, 1, 1, 0, 0, 0, 0, time.UTC)),
+			want: dr.NewDateRange(date, last),
+		},
+		{
+			name: "from, after the last finite date",
+			d:    dr.NewDateRangeFrom(time.Date(3000000, 1, 1, 0, 0, 0, 0, time.UTC)),
+			want: dr.NewDateRangeFrom(last),
+		},
+	}
+	for _, c := range beyond {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			if c.d != c.want {
+				t.Errorf("got %v, want %v", c.d, c.want)
+			}
+		})
+	}
+
+	drs := dr.FromDates(time.Date(3000000, 1, 1, 0, 0, 0, 0, time.UTC))
+	if drs.TotalDays() != 1 || !drs.FirstDate().Equal(last) {
+		t.Errorf("FromDates(3000000-01-01) = %v, want [{%v - %v}]", drs.ToSlice(), last, last)
+	}
+}
+
+// test set operations of dr.DateRange with unbounded ranges
+func TestUnboundedDateRangeOperations(t *testing.T) {
+	jan := dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+	fromFeb := dr.NewDateRangeFrom(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	fromJan15 := dr.NewDateRangeFrom(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+	untilJan10 := dr.NewDateRangeUntil(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))
+	all := dr.NewUnboundedDateRange()
+
+	if !all.Contains(time.Date(-5000000, 1, 1, 0, 0, 0, 0, time.UTC)) || !all.Contains(time.Date(5000000, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("%v does not contain every date", all)
+	}
+	if fromFeb.Contains(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)) || !fromFeb.Contains(time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("%v.Contains() is wrong", fromFeb)
+	}
+	if jan.Overlaps(fromFeb) || !jan.Overlaps(fromJan15) || !untilJan10.Overlaps(jan) || untilJan10.Overlaps(fromFeb) {
+		t.Errorf("Overlaps() is wrong for unbounded ranges")
+	}
+	if !all.Includes(fromFeb) || fromFeb.Includes(all) || !fromJan15.Includes(fromFeb) {
+		t.Errorf("Includes() is wrong for unbounded ranges")
+	}
+
+	if got, want := fromJan15.Intersection(untilJan10), (dr.DateRange{}); got != want {
+		t.Errorf("%v.Intersection(%v) = %v, want %v", fromJan15, untilJan10, got, want)
+	}
+	if got, want := all.Intersection(jan), jan; got != want {
+		t.Errorf("%v.Intersection(%v) = %v, want %v", all, jan, got, want)
+	}
+	if got, want := fromJan15.Intersection(all), fromJan15; got != want {
+		t.Errorf("%v.Intersection(%v) = %v, want %v", fromJan15, all, got, want)
+	}
+
+	union := jan.Union(fromFeb)
+	if want := []dr.DateRange{dr.NewDateRangeFrom(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))}; !reflect.DeepEqual(union.ToSlice(), want) {
+		t.Errorf("%v.Union(%v) = %v, want %v", jan, fromFeb, union, want)
+	}
+	union = untilJan10.Union(fromJan15)
+	if want := []dr.DateRange{untilJan10, fromJan15}; !reflect.DeepEqual(union.ToSlice(), want) {
+		t.Errorf("%v.Union(%v) = %v, want %v", untilJan10, fromJan15, union, want)
+	}
+
+	diff := all.Difference(jan)
+	want := []dr.DateRange{
+		dr.NewDateRangeUntil(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)),
+		fromFeb,
+	}
+	if !reflect.DeepEqual(diff.ToSlice(), want) {
+		t.Errorf("%v.Difference(%v) = %v, want %v", all, jan, diff, want)
+	}
+	diff = jan.Difference(all)
+	if diff.Len() != 0 {
+		t.Errorf("%v.Difference(%v) = %v, want empty", jan, all, diff)
+	}
+
+	if got := all.SplitEvery(7); len(got) != 0 {
+		t.Errorf("%v.SplitEvery(7) = %v, want empty", all, got)
+	}
+	if got, want := fromFeb.AlignOutward(dr.ISOWeek), dr.NewDateRangeFrom(time.Date(2024, 1, 29, 0, 0, 0, 0, time.UTC)); got != want {
+		t.Errorf("%v.AlignOutward(ISOWeek) = %v, want %v", fromFeb, got, want)
+	}
+	if got, want := untilJan10.AlignInward(dr.Month), dr.NewDateRangeUntil(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)); got != want {
+		t.Errorf("%v.AlignInward(Month) = %v, want %v", untilJan10, got, want)
+	}
+}
@@ -7,7 +7,8 @@ import (
 
 // DataRanges is a collection of DateRange elements
 type DateRanges struct {
-	dr []DateRange
+	dr      []DateRange
+	cumDays []int // cumDays[i] is the number of days in dr[0] to dr[i], maintained by normalize
 }
 
 // NewDateRanges returns a new collection with given elements
@@ -20,8 +21,49 @@ func NewDateRanges(dataRanges ...DateRange) DateRanges {
 	return drs
 }
 
+// FromDates returns a new collection covering the given dates. The dates are
+// truncated to the date portion and consecutive dates are merged into ranges.
+// Duplicate dates are allowed and the dates do not need to be sorted.
+func FromDates(dates ...time.Time) DateRanges {
+	return FromDateSeq(func(yield func(time.Time) bool) {
+		for _, date := range dates {
+			if !yield(date) {
+				return
+			}
+		}
+	})
+}
+
+// FromDateSeq is like FromDates, but reads the dates from an iterator with the
+// signature of iter.Seq[time.Time]. Runs of consecutive dates are merged while
+// reading, so mostly sorted inputs use memory proportional to the number of runs.
+func FromDateSeq(seq func(yield func(time.Time) bool)) DateRanges {
+	runs := []DateRange{}
+	seq(func(date time.Time) bool {
+		date = toDateUTC(date)
+		if n := len(runs); n > 0 {
+			last := &runs[n-1]
+			switch {
+			case !date.Before(last.from) && !date.After(last.to):
+				return true
+			case date.Equal(last.to.AddDate(0, 0, 1)):
+				last.to = date
+				return true
+			case date.Equal(last.from.AddDate(0, 0, -1)):
+				last.from = date
+				return true
+			}
+		}
+		runs = append(runs, DateRange{from: date, to: date, nonEmpty: true})
+		return true
+	})
+	drs := DateRanges{dr: runs}
+	drs.normalize()
+	return drs
+}
+
 // ToSlice returns the members of the collection as a slice.
-// Items are guaranteed to be sorted, non-overlapping and non-zero.
+// Items are guaranteed to be sorted, non-overlapping and non-empty.
 // Any adjacent periods are merged.
 func (drs *DateRanges) ToSlice() []DateRange {
 	copySlice := make([]DateRange, len(drs.dr))
@@ -30,7 +72,7 @@ func (drs *DateRanges) ToSlice() []DateRange {
 }
 
 // String returns a string representation of the collection.
-// Items are guaranteed to be sorted, non-overlapping and non-zero.
+// Items are guaranteed to be sorted, non-overlapping and non-empty.
 // Any adjacent periods are merged.
 func (drs DateRanges) String() string {
 	if drs.IsZero() {
@@ -57,7 +99,7 @@ func (drs *DateRanges) Len() int {
 	return len(drs.dr)
 }
 
-// FirstDate returns the first date of the collection
+// FirstDate returns the first date of the collection, or the zero time if it is empty
 func (drs *DateRanges) FirstDate() time.Time {
 	if drs.IsZero() {
 		return time.Time{}
@@ -65,7 +107,13 @@ func (drs *DateRanges) FirstDate() time.Time {
 	return drs.dr[0].from
 }
 
-// LastDate returns the last date of the collection
+// FirstDateOK returns the first date of the collection, or false if it is empty.
+// Unlike FirstDate, it tells an empty collection from one starting on 0001-01-01.
+func (drs *DateRanges) FirstDateOK() (time.Time, bool) {
+	return drs.FirstDate(), !drs.IsZero()
+}
+
+// LastDate returns the last date of the collection, or the zero time if it is empty
 func (drs *DateRanges) LastDate() time.Time {
 	if drs.IsZero() {
 		return time.Time{}
@@ -73,6 +121,12 @@ func (drs *DateRanges) LastDate() time.Time {
 	return drs.dr[len(drs.dr)-1].to
 }
 
+// LastDateOK returns the last date of the collection, or false if it is empty.
+// Unlike LastDate, it tells an empty collection from one ending on 0001-01-01.
+func (drs *DateRanges) LastDateOK() (time.Time, bool) {
+	return drs.LastDate(), !drs.IsZero()
+}
+
 // Equal returns true if the collection is equal to the given collection
 func (drs *DateRanges) Equal(other DateRanges) bool {
 	if len(drs.dr) != len(other.dr) {
@@ -92,6 +146,22 @@ func (drs *DateRanges) Append(dataRange ...DateRange) {
 	drs.normalize()
 }
 
+// Remove removes the dates of the given elements from the collection.
+// Members are split when a hole is removed from their middle.
+func (drs *DateRanges) Remove(dataRange ...DateRange) {
+	*drs = drs.Difference(NewDateRanges(dataRange...))
+}
+
+// AddDate adds the given date to the collection.
+func (drs *DateRanges) AddDate(date time.Time) {
+	drs.Append(NewDateRange(date, date))
+}
+
+// RemoveDate removes the given date from the collection.
+func (drs *DateRanges) RemoveDate(date time.Time) {
+	drs.Remove(NewDateRange(date, date))
+}
+
 // Contains returns true if the given date is in the collection
 func (drs *DateRanges) Contains(date time.Time) bool {
 	for _, dr := range drs.dr {
@@ -103,9 +173,9 @@ func (drs *DateRanges) Contains(date time.Time) bool {
 }
 
 // IsAnyDateIn returns true if any date in the given DateRange is in the collection
-// Zero DateRange is always considered to be in the collection
+// The empty DateRange is always considered to be in the collection
 func (drs *DateRanges) IsAnyDateIn(other DateRange) bool {
-	if other.IsZero() {
+	if other.IsEmpty() {
 		return true
 	}
 	for _, dr := range drs.dr {
@@ -117,9 +187,9 @@ func (drs *DateRanges) IsAnyDateIn(other DateRange) bool {
 }
 
 // IsAllDatesIn returns true if all dates in the given DateRange are in the collection
-// Zero DateRange is always considered to be in the collection
+// The empty DateRange is always considered to be in the collection
 func (drs *DateRanges) IsAllDatesIn(other DateRange) bool {
-	if other.IsZero() {
+	if other.IsEmpty() {
 		return true
 	}
 	for _, dr := range drs.dr {
@@ -141,19 +211,208 @@ func (drs *DateRanges) SplitInclusive(date time.Time) (DateRanges, DateRanges) {
 		} else if dr.from.After(date) {
 			after.Append(dr)
 		} else {
-			before.Append(DateRange{from: dr.from, to: date})
-			after.Append(DateRange{from: date, to: dr.to})
+			before.Append(DateRange{from: dr.from, to: date, nonEmpty: true})
+			after.Append(DateRange{from: date, to: dr.to, nonEmpty: true})
 		}
 	}
 	return before, after
 }
 
+// TotalDays returns the number of dates in the collection.
+// It returns math.MaxInt if the collection is unbounded.
+func (drs *DateRanges) TotalDays() int {
+	if len(drs.cumDays) == 0 {
+		return 0
+	}
+	return drs.cumDays[len(drs.cumDays)-1]
+}
+
+// NthDate returns the n-th date of the collection, counting from 1. Negative
+// values of n count from the end, -1 being the last date. It returns false if
+// n is 0 or out of range, or if the collection has no start, or no end for
+// negative values of n. It runs in O(log n) of the number of members.
+func (drs *DateRanges) NthDate(n int) (time.Time, bool) {
+	if len(drs.dr) == 0 || drs.dr[0].IsFromInf() || (n < 0 && drs.dr[len(drs.dr)-1].IsToInf()) {
+		return time.Time{}, false
+	}
+	total := drs.TotalDays()
+	idx := n - 1
+	if n < 0 {
+		idx = total + n
+	}
+	if n == 0 || idx < 0 || idx >= total {
+		return time.Time{}, false
+	}
+	// first member whose cumulative day count covers idx
+	i := sort.SearchInts(drs.cumDays, idx+1)
+	before := 0
+	if i > 0 {
+		before = drs.cumDays[i-1]
+	}
+	if drs.dr[i].IsToInf() && idx-before >= daysBetween(drs.dr[i].from, posInf) {
+		return time.Time{}, false
+	}
+	return drs.dr[i].from.AddDate(0, 0, idx-before), true
+}
+
+// Rank returns the position of the given date among the dates of the collection,
+// counting from 1. It returns false if the date is not in the collection or
+// if the collection has no start. It runs in O(log n) of the number of members.
+func (drs *DateRanges) Rank(date time.Time) (int, bool) {
+	if len(drs.dr) == 0 || drs.dr[0].IsFromInf() {
+		return 0, false
+	}
+	date = toDateUTC(date)
+	if isPosInf(date) {
+		return 0, false
+	}
+	// first member ending on or after date
+	i := sort.Search(len(drs.dr), func(i int) bool {
+		return !drs.dr[i].to.Before(date)
+	})
+	if i == len(drs.dr) || date.Before(drs.dr[i].from) {
+		return 0, false
+	}
+	before := 0
+	if i > 0 {
+		before = drs.cumDays[i-1]
+	}
+	return before + daysBetween(drs.dr[i].from, date) + 1, true
+}
+
+// Each calls f for every member of the collection in ascending order, without
+// copying the collection, until f returns false.
+// Each has the signature of iter.Seq[DateRange], so drs.Each can be used with
+// range-over-func on Go 1.23 or later.
+func (drs *DateRanges) Each(f func(DateRange) bool) {
+	for _, dr := range drs.dr {
+		if !f(dr) {
+			return
+		}
+	}
+}
+
+// Filter returns a new collection with the members for which keep returns true.
+func (drs *DateRanges) Filter(keep func(DateRange) bool) DateRanges {
+	kept := []DateRange{}
+	for _, dr := range drs.dr {
+		if keep(dr) {
+			kept = append(kept, dr)
+		}
+	}
+	return NewDateRanges(kept...)
+}
+
+// Map returns a new collection with the result of f for every member.
+// The result is normalized: members are sorted, empty ranges removed and
+// overlapping or adjacent members merged.
+func (drs *DateRanges) Map(f func(DateRange) DateRange) DateRanges {
+	mapped := make([]DateRange, 0, len(drs.dr))
+	for _, dr := range drs.dr {
+		mapped = append(mapped, f(dr))
+	}
+	return NewDateRanges(mapped...)
+}
+
+// Clip returns a new collection with the dates of the collection that are within the given bounds.
+func (drs *DateRanges) Clip(bounds DateRange) DateRanges {
+	clipped := []DateRange{}
+	for _, dr := range drs.dr {
+		if dr.Overlaps(bounds) {
+			clipped = append(clipped, dr.Intersection(bounds))
+		}
+	}
+	return NewDateRanges(clipped...)
+}
+
+// Reduce folds the members of the collection in ascending order, starting with initial.
+func Reduce[T any](drs DateRanges, initial T, f func(T, DateRange) T) T {
+	acc := initial
+	for _, dr := range drs.dr {
+		acc = f(acc, dr)
+	}
+	return acc
+}
+
+// Difference returns a new collection with the dates of the collection that
+// are not in the other collection.
+func (drs *DateRanges) Difference(other DateRanges) DateRanges {
+	diff := []DateRange{}
+	j := 0
+	for _, dr := range drs.dr {
+		// skip the members of other that end before this member
+		for j < len(other.dr) && other.dr[j].to.Before(dr.from) {
+			j++
+		}
+		current := dr
+		covered := false
+		for k := j; k < len(other.dr) && !other.dr[k].from.After(current.to); k++ {
+			if other.dr[k].from.After(current.from) {
+				diff = append(diff, DateRange{
+					from:     current.from,
+					to:       other.dr[k].from.AddDate(0, 0, -1),
+					nonEmpty: true,
+				})
+			}
+			if !other.dr[k].to.Before(current.to) {
+				covered = true
+				break
+			}
+			current.from = other.dr[k].to.AddDate(0, 0, 1)
+		}
+		if !covered {
+			diff = append(diff, current)
+		}
+	}
+	return NewDateRanges(diff...)
+}
+
+// Complement returns a new collection with the dates of the given range that
+// are not in the collection, i.e. the gaps of the collection within the range.
+func (drs *DateRanges) Complement(within DateRange) DateRanges {
+	full := NewDateRanges(within)
+	return full.Difference(*drs)
+}
+
+// AlignOutward returns a new collection with every member extended to whole
+// calendar periods of the given Granularity. Members that end up overlapping
+// or adjacent are merged.
+func (drs *DateRanges) AlignOutward(g Granularity) DateRanges {
+	aligned := make([]DateRange, 0, len(drs.dr))
+	for _, dr := range drs.dr {
+		aligned = append(aligned, dr.AlignOutward(g))
+	}
+	return NewDateRanges(aligned...)
+}
+
+// AlignInward returns a new collection with every member shrunk to the whole
+// calendar periods of the given Granularity it contains. Members that contain
+// no complete period are dropped.
+func (drs *DateRanges) AlignInward(g Granularity) DateRanges {
+	aligned := make([]DateRange, 0, len(drs.dr))
+	for _, dr := range drs.dr {
+		aligned = append(aligned, dr.AlignInward(g))
+	}
+	return NewDateRanges(aligned...)
+}
+
+// clone returns a copy of the collection that shares no memory with it.
+func (drs *DateRanges) clone() DateRanges {
+	c := DateRanges{
+		dr:      make([]DateRange, len(drs.dr)),
+		cumDays: append([]int(nil), drs.cumDays...),
+	}
+	copy(c.dr, drs.dr)
+	return c
+}
+
 // normalize sorts the collection and merges overlapping periods
 func (drs *DateRanges) normalize() *DateRanges {
 	if len(drs.dr) == 0 {
+		drs.cumDays = nil
 		return drs
 	}
-	return drs.sort().removeZero().merge()
+	return drs.sort().removeEmpty().merge().index()
 }
 
 // sort sorts the collection
@@ -164,19 +423,15 @@ func (drs *DateRanges) sort() *DateRanges {
 	return drs
 }
 
-// removeZero removes zero periods from a sorted collection
-func (drs *DateRanges) removeZero() *DateRanges {
-	if len(drs.dr) == 0 {
-		return drs
-	}
-	firstNonZero := 0
+// removeEmpty removes empty periods from the collection
+func (drs *DateRanges) removeEmpty() *DateRanges {
+	nonEmpty := drs.dr[:0]
 	for _, dr := range drs.dr {
-		if !dr.IsZero() {
-			break
+		if !dr.IsEmpty() {
+			nonEmpty = append(nonEmpty, dr)
 		}
-		firstNonZero++
 	}
-	drs.dr = drs.dr[firstNonZero:]
+	drs.dr = nonEmpty
 	return drs
 }
 
@@ -204,3 +459,18 @@ func (drs *DateRanges) merge() *DateRanges {
 	drs.dr = merged
 	return drs
 }
+
+// index computes the prefix sums of day counts of a normalized collection
+func (drs *DateRanges) index() *DateRanges {
+	drs.cumDays = nil
+	if len(drs.dr) == 0 {
+		return drs
+	}
+	drs.cumDays = make([]int, len(drs.dr))
+	total := 0
+	for i, dr := range drs.dr {
+		total = addSaturated(total, dr.Days())
+		drs.cumDays[i] = total
+	}
+	return drs
+}
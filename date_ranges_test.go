@@ -1,6 +1,7 @@
 package daterange_test
 
 import (
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -22,9 +23,21 @@ func TestNewDateRanges(t *testing.T) {
 		},
 		{
 			name: "zero",
-			drs:  []dr.DateRange{dr.NewDateRange(time.Time{}, time.Time{}), dr.NewDateRange(time.Time{}, time.Time{})},
+			drs:  []dr.DateRange{{}, {}},
 			want: []dr.DateRange{},
 		},
+		{
+			name: "first date and empty after unbounded",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Time{}, time.Time{}),
+				{},
+				dr.NewDateRangeUntil(time.Date(-5, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []dr.DateRange{
+				dr.NewDateRangeUntil(time.Date(-5, 1, 1, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Time{}, time.Time{}),
+			},
+		},
 		{
 			name: "one day",
 			drs:  []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))},
@@ -69,32 +82,32 @@ func TestNewDateRanges(t *testing.T) {
 		{
 			name: "zero zero",
 			drs: []dr.DateRange{
-				dr.NewDateRange(time.Time{}, time.Time{}), dr.NewDateRange(time.Time{}, time.Time{}),
-				dr.NewDateRange(time.Time{}, time.Time{}), dr.NewDateRange(time.Time{}, time.Time{})},
+				{}, {},
+				{}, {}},
 			want: []dr.DateRange{},
 		},
 		{
 			name: "zero zero zero",
 			drs: []dr.DateRange{
-				dr.NewDateRange(time.Time{}, time.Time{}), dr.NewDateRange(time.Time{}, time.Time{}),
-				dr.NewDateRange(time.Time{}, time.Time{}), dr.NewDateRange(time.Time{}, time.Time{}),
-				dr.NewDateRange(time.Time{}, time.Time{}), dr.NewDateRange(time.Time{}, time.Time{})},
+				{}, {},
+				{}, {},
+				{}, {}},
 			want: []dr.DateRange{},
 		},
 		{
 			name: "zero multiple days zero",
 			drs: []dr.DateRange{
-				dr.NewDateRange(time.Time{}, time.Time{}), dr.NewDateRange(time.Time{}, time.Time{}),
+				{}, {},
 				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 3, 0, 0, 0, 0, time.UTC)),
-				dr.NewDateRange(time.Time{}, time.Time{}), dr.NewDateRange(time.Time{}, time.Time{})},
+				{}, {}},
 			want: []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 3, 0, 0, 0, 0, time.UTC))},
 		},
 		{
 			name: "zero multiple days zero multiple days",
 			drs: []dr.DateRange{
-				dr.NewDateRange(time.Time{}, time.Time{}), dr.NewDateRange(time.Time{}, time.Time{}),
+				{}, {},
 				dr.NewDateRange(time.Date(2019, 1, 9, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 13, 0, 0, 0, 0, time.UTC)),
-				dr.NewDateRange(time.Time{}, time.Time{}), dr.NewDateRange(time.Time{}, time.Time{}),
+				{}, {},
 				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 3, 0, 0, 0, 0, time.UTC))},
 			want: []dr.DateRange{
 				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 3, 0, 0, 0, 0, time.UTC)),
@@ -180,7 +193,7 @@ func TestDateRangesString(t *testing.T) {
 		},
 		{
 			name: "zero",
-			drs:  []dr.DateRange{dr.NewDateRange(time.Time{}, time.Time{}), dr.NewDateRange(time.Time{}, time.Time{})},
+			drs:  []dr.DateRange{{}, {}},
 			want: "[]",
 		},
 		{
@@ -232,7 +245,7 @@ func TestDateRangesIsZero(t *testing.T) {
 		},
 		{
 			name: "zero",
-			drs:  []dr.DateRange{dr.NewDateRange(time.Time{}, time.Time{}), dr.NewDateRange(time.Time{}, time.Time{})},
+			drs:  []dr.DateRange{{}, {}},
 			want: true,
 		},
 		{
@@ -269,7 +282,7 @@ func TestDateRangesAppend(t *testing.T) {
 		{
 			name:          "empty zero",
 			drs:           []dr.DateRange{},
-			newDataRanges: []dr.DateRange{dr.NewDateRange(time.Time{}, time.Time{})},
+			newDataRanges: []dr.DateRange{{}},
 			want:          []dr.DateRange{},
 		},
 		{
@@ -293,7 +306,7 @@ func TestDateRangesAppend(t *testing.T) {
 		{
 			name:          "one day zero",
 			drs:           []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))},
-			newDataRanges: []dr.DateRange{dr.NewDateRange(time.Time{}, time.Time{})},
+			newDataRanges: []dr.DateRange{{}},
 			want:          []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))},
 		},
 		{
@@ -346,7 +359,7 @@ func TestDateRangesLen(t *testing.T) {
 		},
 		{
 			name: "zero",
-			drs:  []dr.DateRange{dr.NewDateRange(time.Time{}, time.Time{}), dr.NewDateRange(time.Time{}, time.Time{})},
+			drs:  []dr.DateRange{{}, {}},
 			want: 0,
 		},
 		{
@@ -377,24 +390,34 @@ func TestDateRangesLen(t *testing.T) {
 // test dr.DateRanges.FirstDate
 func TestDateRangesFirstDate(t *testing.T) {
 	cases := []struct {
-		name string
-		drs  []dr.DateRange
-		want time.Time
+		name   string
+		drs    []dr.DateRange
+		want   time.Time
+		wantOK bool
 	}{
 		{
-			name: "empty",
-			drs:  []dr.DateRange{},
-			want: time.Time{},
+			name:   "empty",
+			drs:    []dr.DateRange{},
+			want:   time.Time{},
+			wantOK: false,
 		},
 		{
-			name: "zero",
-			drs:  []dr.DateRange{dr.NewDateRange(time.Time{}, time.Time{}), dr.NewDateRange(time.Time{}, time.Time{})},
-			want: time.Time{},
+			name:   "zero",
+			drs:    []dr.DateRange{{}, {}},
+			want:   time.Time{},
+			wantOK: false,
 		},
 		{
-			name: "one day",
-			drs:  []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))},
-			want: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			name:   "zero time",
+			drs:    []dr.DateRange{dr.NewDateRange(time.Time{}, time.Time{})},
+			want:   time.Time{},
+			wantOK: true,
+		},
+		{
+			name:   "one day",
+			drs:    []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))},
+			want:   time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantOK: true,
 		},
 		{
 			name: "two days two days",
@@ -402,7 +425,8 @@ func TestDateRangesFirstDate(t *testing.T) {
 				dr.NewDateRange(time.Date(2019, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 6, 0, 0, 0, 0, time.UTC)),
 				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)),
 			},
-			want: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:   time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantOK: true,
 		},
 	}
 	for _, c := range cases {
@@ -412,6 +436,9 @@ func TestDateRangesFirstDate(t *testing.T) {
 			if !got.FirstDate().Equal(c.want) {
 				t.Errorf("NewDateRanges(%v).FirstDate() = %v, want %v", c.drs, got, c.want)
 			}
+			if date, ok := got.FirstDateOK(); !date.Equal(c.want) || ok != c.wantOK {
+				t.Errorf("NewDateRanges(%v).FirstDateOK() = %v, %v, want %v, %v", c.drs, date, ok, c.want, c.wantOK)
+			}
 		})
 	}
 }
@@ -419,24 +446,34 @@ func TestDateRangesFirstDate(t *testing.T) {
 // test dr.DateRanges.LastDate
 func TestDateRangesLastDate(t *testing.T) {
 	cases := []struct {
-		name string
-		drs  []dr.DateRange
-		want time.Time
+		name   string
+		drs    []dr.DateRange
+		want   time.Time
+		wantOK bool
 	}{
 		{
-			name: "empty",
-			drs:  []dr.DateRange{},
-			want: time.Time{},
+			name:   "empty",
+			drs:    []dr.DateRange{},
+			want:   time.Time{},
+			wantOK: false,
 		},
 		{
-			name: "zero",
-			drs:  []dr.DateRange{dr.NewDateRange(time.Time{}, time.Time{}), dr.NewDateRange(time.Time{}, time.Time{})},
-			want: time.Time{},
+			name:   "zero",
+			drs:    []dr.DateRange{{}, {}},
+			want:   time.Time{},
+			wantOK: false,
 		},
 		{
-			name: "one day",
-			drs:  []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))},
-			want: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			name:   "zero time",
+			drs:    []dr.DateRange{dr.NewDateRange(time.Time{}, time.Time{})},
+			want:   time.Time{},
+			wantOK: true,
+		},
+		{
+			name:   "one day",
+			drs:    []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))},
+			want:   time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantOK: true,
 		},
 		{
 			name: "two days two days",
@@ -444,7 +481,8 @@ func TestDateRangesLastDate(t *testing.T) {
 				dr.NewDateRange(time.Date(2019, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 6, 0, 0, 0, 0, time.UTC)),
 				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)),
 			},
-			want: time.Date(2019, 1, 6, 0, 0, 0, 0, time.UTC),
+			want:   time.Date(2019, 1, 6, 0, 0, 0, 0, time.UTC),
+			wantOK: true,
 		},
 	}
 	for _, c := range cases {
@@ -454,6 +492,9 @@ func TestDateRangesLastDate(t *testing.T) {
 			if !got.LastDate().Equal(c.want) {
 				t.Errorf("NewDateRanges(%v).LastDate() = %v, want %v", c.drs, got, c.want)
 			}
+			if date, ok := got.LastDateOK(); !date.Equal(c.want) || ok != c.wantOK {
+				t.Errorf("NewDateRanges(%v).LastDateOK() = %v, %v, want %v, %v", c.drs, date, ok, c.want, c.wantOK)
+			}
 		})
 	}
 }
@@ -619,7 +660,7 @@ func TestDateRangesIsAnyDateIn(t *testing.T) {
 		{
 			name: "empty zero",
 			drs:  []dr.DateRange{},
-			dr:   dr.NewDateRange(time.Time{}, time.Time{}),
+			dr:   dr.DateRange{},
 			want: true,
 		},
 		{
@@ -633,7 +674,7 @@ func TestDateRangesIsAnyDateIn(t *testing.T) {
 			drs: []dr.DateRange{
 				dr.NewDateRange(time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 4, 0, 0, 0, 0, time.UTC)),
 			},
-			dr:   dr.NewDateRange(time.Time{}, time.Time{}),
+			dr:   dr.DateRange{},
 			want: true,
 		},
 		{
@@ -710,7 +751,7 @@ func TestDateRangesIsAllDatesIn(t *testing.T) {
 		{
 			name: "empty zero",
 			drs:  []dr.DateRange{},
-			dr:   dr.NewDateRange(time.Time{}, time.Time{}),
+			dr:   dr.DateRange{},
 			want: true,
 		},
 		{
@@ -724,7 +765,7 @@ func TestDateRangesIsAllDatesIn(t *testing.T) {
 			drs: []dr.DateRange{
 				dr.NewDateRange(time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 4, 0, 0, 0, 0, time.UTC)),
 			},
-			dr:   dr.NewDateRange(time.Time{}, time.Time{}),
+			dr:   dr.DateRange{},
 			want: true,
 		},
 		{
@@ -913,3 +954,892 @@ func TestDateRangesSplitInclusive(t *testing.T) {
 		})
 	}
 }
+
+// test dr.DateRanges.AlignOutward
+func TestDateRangesAlignOutward(t *testing.T) {
+	cases := []struct {
+		name string
+		drs  []dr.DateRange
+		g    dr.Granularity
+		want []dr.DateRange
+	}{
+		{
+			name: "empty",
+			drs:  []dr.DateRange{},
+			g:    dr.Month,
+			want: []dr.DateRange{},
+		},
+		{
+			name: "members merged after alignment",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 11, 0, 0, 0, 0, time.UTC)),
+			},
+			g: dr.Month,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(c.drs...)
+			got := drs.AlignOutward(c.g)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("NewDateRanges(%v).AlignOutward(%v) = %v, want %v", c.drs, c.g, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.AlignInward
+func TestDateRangesAlignInward(t *testing.T) {
+	cases := []struct {
+		name string
+		drs  []dr.DateRange
+		g    dr.Granularity
+		want []dr.DateRange
+	}{
+		{
+			name: "empty",
+			drs:  []dr.DateRange{},
+			g:    dr.ISOWeek,
+			want: []dr.DateRange{},
+		},
+		{
+			name: "members without complete periods dropped",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 28, 0, 0, 0, 0, time.UTC)),
+			},
+			g: dr.ISOWeek,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 28, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(c.drs...)
+			got := drs.AlignInward(c.g)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("NewDateRanges(%v).AlignInward(%v) = %v, want %v", c.drs, c.g, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.FromDates
+func TestFromDates(t *testing.T) {
+	cases := []struct {
+		name  string
+		dates []time.Time
+		want  []dr.DateRange
+	}{
+		{
+			name:  "empty",
+			dates: []time.Time{},
+			want:  []dr.DateRange{},
+		},
+		{
+			name: "unsorted with duplicates and time portion",
+			dates: []time.Time{
+				time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 2, 13, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 4, 21, 0, 0, 0, time.FixedZone("EST", -5*60*60)),
+				time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+			},
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "descending",
+			dates: []time.Time{
+				time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := dr.FromDates(c.dates...)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("FromDates(%v) = %v, want %v", c.dates, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.FromDateSeq
+func TestFromDateSeq(t *testing.T) {
+	// every day of 2024 except Sundays
+	seq := func(yield func(time.Time) bool) {
+		for date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); date.Year() == 2024; date = date.AddDate(0, 0, 1) {
+			if date.Weekday() == time.Sunday {
+				continue
+			}
+			if !yield(date) {
+				return
+			}
+		}
+	}
+	got := dr.FromDateSeq(seq)
+	if got.Len() != 53 {
+		t.Errorf("FromDateSeq(...).Len() = %v, want 53", got.Len())
+	}
+	if want := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); got.FirstDate() != want {
+		t.Errorf("FromDateSeq(...).FirstDate() = %v, want %v", got.FirstDate(), want)
+	}
+	if want := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC); got.LastDate() != want {
+		t.Errorf("FromDateSeq(...).LastDate() = %v, want %v", got.LastDate(), want)
+	}
+}
+
+// test dr.DateRanges.Difference
+func TestDateRangesDifference(t *testing.T) {
+	cases := []struct {
+		name  string
+		drs   []dr.DateRange
+		other []dr.DateRange
+		want  []dr.DateRange
+	}{
+		{
+			name:  "empty empty",
+			drs:   []dr.DateRange{},
+			other: []dr.DateRange{},
+			want:  []dr.DateRange{},
+		},
+		{
+			name: "empty other",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC)),
+			},
+			other: []dr.DateRange{},
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "other covers all",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2019, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 9, 0, 0, 0, 0, time.UTC)),
+			},
+			other: []dr.DateRange{
+				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 10, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []dr.DateRange{},
+		},
+		{
+			name: "holes and overhangs",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 20, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2019, 1, 25, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC)),
+			},
+			other: []dr.DateRange{
+				dr.NewDateRange(time.Date(2018, 12, 25, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 6, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2019, 1, 19, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 26, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2019, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 4, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2019, 1, 7, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 18, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2019, 1, 27, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 30, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(c.drs...)
+			got := drs.Difference(dr.NewDateRanges(c.other...))
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("NewDateRanges(%v).Difference(%v) = %v, want %v", c.drs, c.other, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.Complement
+func TestDateRangesComplement(t *testing.T) {
+	cases := []struct {
+		name   string
+		drs    []dr.DateRange
+		within dr.DateRange
+		want   []dr.DateRange
+	}{
+		{
+			name:   "zero within",
+			drs:    []dr.DateRange{},
+			within: dr.DateRange{},
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "empty collection",
+			drs:    []dr.DateRange{},
+			within: dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "gaps",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 10, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2019, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 20, 0, 0, 0, 0, time.UTC)),
+			},
+			within: dr.NewDateRange(time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2019, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 14, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2019, 1, 21, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(c.drs...)
+			got := drs.Complement(c.within)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("NewDateRanges(%v).Complement(%v) = %v, want %v", c.drs, c.within, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.Each
+func TestDateRangesEach(t *testing.T) {
+	drs := dr.NewDateRanges(
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+	)
+	got := []dr.DateRange{}
+	drs.Each(func(d dr.DateRange) bool {
+		got = append(got, d)
+		return true
+	})
+	if !reflect.DeepEqual(got, drs.ToSlice()) {
+		t.Errorf("%v.Each() visited %v, want %v", drs, got, drs.ToSlice())
+	}
+
+	got = []dr.DateRange{}
+	drs.Each(func(d dr.DateRange) bool {
+		got = append(got, d)
+		return len(got) < 2
+	})
+	if !reflect.DeepEqual(got, drs.ToSlice()[:2]) {
+		t.Errorf("%v.Each() with stop visited %v, want %v", drs, got, drs.ToSlice()[:2])
+	}
+}
+
+// test dr.DateRanges.Filter
+func TestDateRangesFilter(t *testing.T) {
+	drs := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+	}
+	cases := []struct {
+		name string
+		keep func(dr.DateRange) bool
+		want []dr.DateRange
+	}{
+		{
+			name: "keep all",
+			keep: func(dr.DateRange) bool { return true },
+			want: drs,
+		},
+		{
+			name: "keep none",
+			keep: func(dr.DateRange) bool { return false },
+			want: []dr.DateRange{},
+		},
+		{
+			name: "keep multi day",
+			keep: func(d dr.DateRange) bool { return d.Days() > 1 },
+			want: []dr.DateRange{drs[0], drs[2]},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(drs...)
+			got := drs.Filter(c.keep)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("NewDateRanges(%v).Filter() = %v, want %v", drs, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.Map
+func TestDateRangesMap(t *testing.T) {
+	drs := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+	}
+	cases := []struct {
+		name string
+		f    func(dr.DateRange) dr.DateRange
+		want []dr.DateRange
+	}{
+		{
+			name: "identity",
+			f:    func(d dr.DateRange) dr.DateRange { return d },
+			want: drs,
+		},
+		{
+			name: "to zero",
+			f:    func(dr.DateRange) dr.DateRange { return dr.DateRange{} },
+			want: []dr.DateRange{},
+		},
+		{
+			name: "overlapping result is merged",
+			f: func(d dr.DateRange) dr.DateRange {
+				return dr.NewDateRange(d.From(), d.To().AddDate(0, 0, 3))
+			},
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "reordering result is sorted",
+			f: func(d dr.DateRange) dr.DateRange {
+				if d.Days() == 1 {
+					return dr.NewDateRange(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC))
+				}
+				return d
+			},
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)),
+				drs[0],
+				drs[2],
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(drs...)
+			got := drs.Map(c.f)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("NewDateRanges(%v).Map() = %v, want %v", drs, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.Clip
+func TestDateRangesClip(t *testing.T) {
+	drs := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+	}
+	cases := []struct {
+		name   string
+		bounds dr.DateRange
+		want   []dr.DateRange
+	}{
+		{
+			name:   "zero bounds",
+			bounds: dr.DateRange{},
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "bounds outside",
+			bounds: dr.NewDateRange(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)),
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "bounds cut both ends",
+			bounds: dr.NewDateRange(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+				drs[1],
+				dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(drs...)
+			got := drs.Clip(c.bounds)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("NewDateRanges(%v).Clip(%v) = %v, want %v", drs, c.bounds, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.Reduce
+func TestReduce(t *testing.T) {
+	cases := []struct {
+		name string
+		drs  []dr.DateRange
+		want int
+	}{
+		{
+			name: "empty",
+			drs:  []dr.DateRange{},
+			want: 0,
+		},
+		{
+			name: "total days",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+			},
+			want: 6,
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := dr.Reduce(dr.NewDateRanges(c.drs...), 0, func(total int, d dr.DateRange) int {
+				return total + d.Days()
+			})
+			if got != c.want {
+				t.Errorf("Reduce(%v) = %v, want %v", c.drs, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.TotalDays
+func TestDateRangesTotalDays(t *testing.T) {
+	cases := []struct {
+		name string
+		drs  []dr.DateRange
+		want int
+	}{
+		{
+			name: "empty",
+			drs:  []dr.DateRange{},
+			want: 0,
+		},
+		{
+			name: "multiple",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+			},
+			want: 34,
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(c.drs...)
+			if got := drs.TotalDays(); got != c.want {
+				t.Errorf("NewDateRanges(%v).TotalDays() = %v, want %v", c.drs, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.NthDate
+func TestDateRangesNthDate(t *testing.T) {
+	drs := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC)),
+	}
+	cases := []struct {
+		name   string
+		drs    []dr.DateRange
+		n      int
+		want   time.Time
+		wantOk bool
+	}{
+		{
+			name:   "empty",
+			drs:    []dr.DateRange{},
+			n:      1,
+			wantOk: false,
+		},
+		{
+			name:   "zero",
+			drs:    drs,
+			n:      0,
+			wantOk: false,
+		},
+		{
+			name:   "first",
+			drs:    drs,
+			n:      1,
+			want:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "end of first member",
+			drs:    drs,
+			n:      3,
+			want:   time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "start of second member",
+			drs:    drs,
+			n:      4,
+			want:   time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "inside third member",
+			drs:    drs,
+			n:      7,
+			want:   time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "last",
+			drs:    drs,
+			n:      10,
+			want:   time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "past last",
+			drs:    drs,
+			n:      11,
+			wantOk: false,
+		},
+		{
+			name:   "negative last",
+			drs:    drs,
+			n:      -1,
+			want:   time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "negative inside second member",
+			drs:    drs,
+			n:      -6,
+			want:   time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "negative first",
+			drs:    drs,
+			n:      -10,
+			want:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "negative past first",
+			drs:    drs,
+			n:      -11,
+			wantOk: false,
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(c.drs...)
+			got, ok := drs.NthDate(c.n)
+			if got != c.want || ok != c.wantOk {
+				t.Errorf("NewDateRanges(%v).NthDate(%v) = %v, %v, want %v, %v", c.drs, c.n, got, ok, c.want, c.wantOk)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.Rank
+func TestDateRangesRank(t *testing.T) {
+	drs := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC)),
+	}
+	cases := []struct {
+		name   string
+		drs    []dr.DateRange
+		date   time.Time
+		want   int
+		wantOk bool
+	}{
+		{
+			name:   "empty",
+			drs:    []dr.DateRange{},
+			date:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantOk: false,
+		},
+		{
+			name:   "before first",
+			drs:    drs,
+			date:   time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+			wantOk: false,
+		},
+		{
+			name:   "first",
+			drs:    drs,
+			date:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want:   1,
+			wantOk: true,
+		},
+		{
+			name:   "in gap",
+			drs:    drs,
+			date:   time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			wantOk: false,
+		},
+		{
+			name:   "second member with time",
+			drs:    drs,
+			date:   time.Date(2024, 1, 9, 15, 0, 0, 0, time.UTC),
+			want:   5,
+			wantOk: true,
+		},
+		{
+			name:   "last",
+			drs:    drs,
+			date:   time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC),
+			want:   10,
+			wantOk: true,
+		},
+		{
+			name:   "after last",
+			drs:    drs,
+			date:   time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+			wantOk: false,
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(c.drs...)
+			got, ok := drs.Rank(c.date)
+			if got != c.want || ok != c.wantOk {
+				t.Errorf("NewDateRanges(%v).Rank(%v) = %v, %v, want %v, %v", c.drs, c.date, got, ok, c.want, c.wantOk)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.Remove
+func TestDateRangesRemove(t *testing.T) {
+	cases := []struct {
+		name    string
+		drs     []dr.DateRange
+		removed []dr.DateRange
+		want    []dr.DateRange
+	}{
+		{
+			name:    "empty",
+			drs:     []dr.DateRange{},
+			removed: []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC))},
+			want:    []dr.DateRange{},
+		},
+		{
+			name:    "nothing removed",
+			drs:     []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC))},
+			removed: []dr.DateRange{},
+			want:    []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:    "hole in the middle",
+			drs:     []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 10, 0, 0, 0, 0, time.UTC))},
+			removed: []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 6, 0, 0, 0, 0, time.UTC))},
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 3, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2019, 1, 7, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 10, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "multiple across members",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 10, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2019, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 20, 0, 0, 0, 0, time.UTC)),
+			},
+			removed: []dr.DateRange{
+				dr.NewDateRange(time.Date(2019, 1, 18, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 25, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2019, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 16, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 7, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2019, 1, 17, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 17, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(c.drs...)
+			drs.Remove(c.removed...)
+			if !reflect.DeepEqual(drs.ToSlice(), c.want) {
+				t.Errorf("NewDateRanges(%v).Remove(%v) = %v, want %v", c.drs, c.removed, drs, c.want)
+			}
+			want := dr.NewDateRanges(c.want...)
+			if got, want := drs.TotalDays(), want.TotalDays(); got != want {
+				t.Errorf("NewDateRanges(%v).Remove(%v).TotalDays() = %v, want %v", c.drs, c.removed, got, want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.AddDate
+func TestDateRangesAddDate(t *testing.T) {
+	cases := []struct {
+		name string
+		drs  []dr.DateRange
+		date time.Time
+		want []dr.DateRange
+	}{
+		{
+			name: "empty",
+			drs:  []dr.DateRange{},
+			date: time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC),
+			want: []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name: "already in",
+			drs:  []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC))},
+			date: time.Date(2019, 1, 3, 0, 0, 0, 0, time.UTC),
+			want: []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name: "fills a gap",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2019, 1, 7, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 9, 0, 0, 0, 0, time.UTC)),
+			},
+			date: time.Date(2019, 1, 6, 0, 0, 0, 0, time.UTC),
+			want: []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 9, 0, 0, 0, 0, time.UTC))},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(c.drs...)
+			drs.AddDate(c.date)
+			if !reflect.DeepEqual(drs.ToSlice(), c.want) {
+				t.Errorf("NewDateRanges(%v).AddDate(%v) = %v, want %v", c.drs, c.date, drs, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.RemoveDate
+func TestDateRangesRemoveDate(t *testing.T) {
+	cases := []struct {
+		name string
+		drs  []dr.DateRange
+		date time.Time
+		want []dr.DateRange
+	}{
+		{
+			name: "not in",
+			drs:  []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC))},
+			date: time.Date(2019, 1, 7, 0, 0, 0, 0, time.UTC),
+			want: []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name: "single day member",
+			drs:  []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))},
+			date: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: []dr.DateRange{},
+		},
+		{
+			name: "blackout day in the middle",
+			drs:  []dr.DateRange{dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC))},
+			date: time.Date(2019, 1, 3, 23, 0, 0, 0, time.UTC),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2019, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 5, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(c.drs...)
+			drs.RemoveDate(c.date)
+			if !reflect.DeepEqual(drs.ToSlice(), c.want) {
+				t.Errorf("NewDateRanges(%v).RemoveDate(%v) = %v, want %v", c.drs, c.date, drs, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges with unbounded members
+func TestDateRangesUnbounded(t *testing.T) {
+	drs := dr.NewDateRanges(
+		dr.NewDateRange(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRangeFrom(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)),
+	)
+	want := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRangeFrom(time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)),
+	}
+	if !reflect.DeepEqual(drs.ToSlice(), want) {
+		t.Fatalf("NewDateRanges() = %v, want %v", drs, want)
+	}
+	if got := drs.String(); got != "[{2024-03-01 - 2024-03-10} {2024-05-20 - +inf}]" {
+		t.Errorf("String() = %v", got)
+	}
+	if got := drs.TotalDays(); got != math.MaxInt {
+		t.Errorf("TotalDays() = %v, want %v", got, math.MaxInt)
+	}
+	if got, ok := drs.NthDate(12); !ok || !got.Equal(time.Date(2024, 5, 21, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("NthDate(12) = %v, %v, want 2024-05-21", got, ok)
+	}
+	if _, ok := drs.NthDate(-1); ok {
+		t.Errorf("NthDate(-1) found a last date of a collection with no end")
+	}
+	if _, ok := drs.NthDate(math.MaxInt - 1); ok {
+		t.Errorf("NthDate(math.MaxInt - 1) found a date")
+	}
+	if got, ok := drs.Rank(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); !ok || got != 237 {
+		t.Errorf("Rank(2025-01-01) = %v, %v, want 237", got, ok)
+	}
+
+	complement := drs.Complement(dr.NewUnboundedDateRange())
+	wantComplement := []dr.DateRange{
+		dr.NewDateRangeUntil(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC)),
+	}
+	if !reflect.DeepEqual(complement.ToSlice(), wantComplement) {
+		t.Errorf("Complement(unbounded) = %v, want %v", complement, wantComplement)
+	}
+	if _, ok := complement.NthDate(1); ok {
+		t.Errorf("NthDate(1) found a first date of a collection with no start")
+	}
+	if _, ok := complement.Rank(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("Rank() found a position in a collection with no start")
+	}
+
+	eroded := drs.Erode(5)
+	wantEroded := []dr.DateRange{dr.NewDateRangeFrom(time.Date(2024, 5, 25, 0, 0, 0, 0, time.UTC))}
+	if !reflect.DeepEqual(eroded.ToSlice(), wantEroded) {
+		t.Errorf("Erode(5) = %v, want %v", eroded, wantEroded)
+	}
+	dilated := complement.Dilate(5)
+	wantDilated := []dr.DateRange{dr.NewDateRangeUntil(time.Date(2024, 5, 24, 0, 0, 0, 0, time.UTC))}
+	if !reflect.DeepEqual(dilated.ToSlice(), wantDilated) {
+		t.Errorf("Dilate(5) = %v, want %v", dilated, wantDilated)
+	}
+}
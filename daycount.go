@@ -0,0 +1,259 @@
+package daterange
+
+import (
+	"math"
+	"time"
+)
+
+// DayCountConvention computes the accrual of interest over a DateRange.
+//
+// Accrual runs from the first date of the range up to, but excluding, the day
+// after its last date, so the range 2024-01-01 to 2024-01-31 accrues over the
+// period from 2024-01-01 to 2024-02-01, that is 31 actual days.
+// An empty DateRange has a day count and a year fraction of 0, an unbounded one
+// a day count of math.MaxInt and a year fraction of +Inf.
+type DayCountConvention interface {
+	// DayCount returns the number of days of the accrual period, as counted by the convention.
+	DayCount(dr DateRange) int
+	// YearFraction returns the length of the accrual period in years.
+	YearFraction(dr DateRange) float64
+}
+
+// Act360 is the ACT/360 convention: actual days divided by 360.
+type Act360 struct{}
+
+// DayCount returns the actual number of days.
+func (Act360) DayCount(dr DateRange) int {
+	return dr.Days()
+}
+
+// YearFraction returns the actual number of days divided by 360.
+func (c Act360) YearFraction(dr DateRange) float64 {
+	if !dr.IsBounded() {
+		return math.Inf(1)
+	}
+	return float64(c.DayCount(dr)) / 360
+}
+
+// Act365Fixed is the ACT/365 Fixed convention: actual days divided by 365.
+type Act365Fixed struct{}
+
+// DayCount returns the actual number of days.
+func (Act365Fixed) DayCount(dr DateRange) int {
+	return dr.Days()
+}
+
+// YearFraction returns the actual number of days divided by 365.
+func (c Act365Fixed) YearFraction(dr DateRange) float64 {
+	if !dr.IsBounded() {
+		return math.Inf(1)
+	}
+	return float64(c.DayCount(dr)) / 365
+}
+
+// ActActISDA is the ACT/ACT ISDA convention: the days falling in leap years are
+// divided by 366 and the other days by 365.
+type ActActISDA struct{}
+
+// DayCount returns the actual number of days.
+func (ActActISDA) DayCount(dr DateRange) int {
+	return dr.Days()
+}
+
+// YearFraction returns the sum of the days in every calendar year of the
+// period, divided by the number of days of that year.
+func (ActActISDA) YearFraction(dr DateRange) float64 {
+	if !dr.IsBounded() {
+		return math.Inf(1)
+	}
+	if dr.IsEmpty() {
+		return 0
+	}
+	start, end := dr.from, dr.to.AddDate(0, 0, 1)
+	fraction := 0.0
+	for year := start.Year(); year <= end.Year(); year++ {
+		yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		yearEnd := yearStart.AddDate(1, 0, 0)
+		from, to := maxTime(start, yearStart), minTime(end, yearEnd)
+		if from.Before(to) {
+			fraction += float64(daysBetween(from, to)) / float64(daysBetween(yearStart, yearEnd))
+		}
+	}
+	return fraction
+}
+
+// ActActICMA is the ACT/ACT ICMA convention used for bonds: the days of every
+// coupon period are divided by Frequency times the length of that coupon period.
+//
+// Coupon dates fall every 12/Frequency months before and after Anchor, keeping the
+// day of month of Anchor when possible and using the last day of shorter months
+// otherwise. Accrual periods that do not match a coupon period, such as short
+// or long stubs, are split over the notional coupon periods they overlap.
+type ActActICMA struct {
+	Frequency int        // coupons per year, one of 1, 2, 3, 4, 6 or 12
+	Anchor    *time.Time // a coupon date, the end of the accrual period if nil
+}
+
+// DayCount returns the actual number of days.
+func (ActActICMA) DayCount(dr DateRange) int {
+	return dr.Days()
+}
+
+// YearFraction returns the year fraction of the accrual period. It returns 0
+// if Frequency is not a divisor of 12.
+func (c ActActICMA) YearFraction(dr DateRange) float64 {
+	if !dr.IsBounded() {
+		return math.Inf(1)
+	}
+	if dr.IsEmpty() || c.Frequency < 1 || 12%c.Frequency != 0 {
+		return 0
+	}
+	start, end := dr.from, dr.to.AddDate(0, 0, 1)
+	anchor := end
+	if c.Anchor != nil {
+		anchor = toDateUTC(*c.Anchor)
+	}
+	step := 12 / c.Frequency
+
+	// find the coupon period containing the start of the accrual
+	months := (start.Year()-anchor.Year())*12 + int(start.Month()-anchor.Month())
+	k := months / step
+	if months < 0 && months%step != 0 {
+		k--
+	}
+	for addMonthsClamped(anchor, k*step).After(start) {
+		k--
+	}
+	for !addMonthsClamped(anchor, (k+1)*step).After(start) {
+		k++
+	}
+
+	fraction := 0.0
+	for couponStart := addMonthsClamped(anchor, k*step); couponStart.Before(end); k++ {
+		couponEnd := addMonthsClamped(anchor, (k+1)*step)
+		from, to := maxTime(start, couponStart), minTime(end, couponEnd)
+		fraction += float64(daysBetween(from, to)) / float64(c.Frequency*daysBetween(couponStart, couponEnd))
+		couponStart = couponEnd
+	}
+	return fraction
+}
+
+// Thirty360US is the 30/360 US convention, also known as 30U/360 or Bond Basis.
+// If EndOfMonth is true, the last day of February is counted as the 30th, as
+// for instruments paying on the last day of every month.
+type Thirty360US struct {
+	EndOfMonth bool
+}
+
+// DayCount returns the number of days counting every month as 30 days.
+func (c Thirty360US) DayCount(dr DateRange) int {
+	if !dr.IsBounded() {
+		return math.MaxInt
+	}
+	if dr.IsEmpty() {
+		return 0
+	}
+	start, end := dr.from, dr.to.AddDate(0, 0, 1)
+	d1, d2 := start.Day(), end.Day()
+	if c.EndOfMonth && isLastOfFebruary(start) {
+		if isLastOfFebruary(end) {
+			d2 = 30
+		}
+		d1 = 30
+	}
+	if d2 == 31 && d1 >= 30 {
+		d2 = 30
+	}
+	if d1 == 31 {
+		d1 = 30
+	}
+	return thirty360(start, end, d1, d2)
+}
+
+// YearFraction returns the day count divided by 360.
+func (c Thirty360US) YearFraction(dr DateRange) float64 {
+	if !dr.IsBounded() {
+		return math.Inf(1)
+	}
+	return float64(c.DayCount(dr)) / 360
+}
+
+// Thirty360E is the 30E/360 convention, also known as Eurobond Basis.
+type Thirty360E struct{}
+
+// DayCount returns the number of days counting every month as 30 days.
+func (Thirty360E) DayCount(dr DateRange) int {
+	if !dr.IsBounded() {
+		return math.MaxInt
+	}
+	if dr.IsEmpty() {
+		return 0
+	}
+	start, end := dr.from, dr.to.AddDate(0, 0, 1)
+	d1, d2 := start.Day(), end.Day()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 {
+		d2 = 30
+	}
+	return thirty360(start, end, d1, d2)
+}
+
+// YearFraction returns the day count divided by 360.
+func (c Thirty360E) YearFraction(dr DateRange) float64 {
+	if !dr.IsBounded() {
+		return math.Inf(1)
+	}
+	return float64(c.DayCount(dr)) / 360
+}
+
+// Thirty360EISDA is the 30E/360 ISDA convention. The last day of every month
+// is counted as the 30th, except the end of the period if it is Maturity and
+// falls in February.
+type Thirty360EISDA struct {
+	Maturity *time.Time // the maturity date of the instrument, nil if unknown
+}
+
+// DayCount returns the number of days counting every month as 30 days.
+func (c Thirty360EISDA) DayCount(dr DateRange) int {
+	if !dr.IsBounded() {
+		return math.MaxInt
+	}
+	if dr.IsEmpty() {
+		return 0
+	}
+	start, end := dr.from, dr.to.AddDate(0, 0, 1)
+	d1, d2 := start.Day(), end.Day()
+	if isLastOfMonth(start) {
+		d1 = 30
+	}
+	isMaturity := c.Maturity != nil && end.Equal(toDateUTC(*c.Maturity))
+	if isLastOfMonth(end) && !(isMaturity && end.Month() == time.February) {
+		d2 = 30
+	}
+	return thirty360(start, end, d1, d2)
+}
+
+// YearFraction returns the day count divided by 360.
+func (c Thirty360EISDA) YearFraction(dr DateRange) float64 {
+	if !dr.IsBounded() {
+		return math.Inf(1)
+	}
+	return float64(c.DayCount(dr)) / 360
+}
+
+// thirty360 returns the 30/360 day count between two dates using the given adjusted days.
+func thirty360(start, end time.Time, d1, d2 int) int {
+	return 360*(end.Year()-start.Year()) + 30*int(end.Month()-start.Month()) + d2 - d1
+}
+
+// isLastOfMonth returns true if the date is the last day of its month.
+func isLastOfMonth(date time.Time) bool {
+	return date.AddDate(0, 0, 1).Day() == 1
+}
+
+// isLastOfFebruary returns true if the date is the last day of February.
+func isLastOfFebruary(date time.Time) bool {
+	return date.Month() == time.February && isLastOfMonth(date)
+}
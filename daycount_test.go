@@ -0,0 +1,228 @@
+package daterange_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// accrual returns the DateRange accruing from start up to, but excluding, end.
+func accrual(start, end time.Time) dr.DateRange {
+	return dr.NewDateRange(start, end.AddDate(0, 0, -1))
+}
+
+// test dr.DayCountConvention implementations against the examples of the ISDA
+// paper "EMU and market conventions: recent developments" (1998)
+func TestDayCountConventionActAct(t *testing.T) {
+	cases := []struct {
+		name     string
+		dr       dr.DateRange
+		icma     dr.ActActICMA
+		wantDays int
+		wantISDA float64
+		wantICMA float64
+	}{
+		{
+			name:     "regular semi-annual period",
+			dr:       accrual(time.Date(2003, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2004, 5, 1, 0, 0, 0, 0, time.UTC)),
+			icma:     dr.ActActICMA{Frequency: 2},
+			wantDays: 182,
+			wantISDA: 0.49772438056740776, // 61/365 + 121/366
+			wantICMA: 0.5,                 // 182/(2*182)
+		},
+		{
+			name:     "short first period",
+			dr:       accrual(time.Date(1999, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(1999, 7, 1, 0, 0, 0, 0, time.UTC)),
+			icma:     dr.ActActICMA{Frequency: 1},
+			wantDays: 150,
+			wantISDA: 0.410958904109589, // 150/365
+			wantICMA: 0.410958904109589, // 150/(1*365)
+		},
+		{
+			name:     "long first period",
+			dr:       accrual(time.Date(2002, 8, 15, 0, 0, 0, 0, time.UTC), time.Date(2003, 7, 15, 0, 0, 0, 0, time.UTC)),
+			icma:     dr.ActActICMA{Frequency: 2},
+			wantDays: 334,
+			wantISDA: 0.915068493150685,  // 138/365 + 196/365
+			wantICMA: 0.9157608695652174, // 153/(2*184) + 181/(2*181)
+		},
+		{
+			name:     "short final period",
+			dr:       accrual(time.Date(2000, 1, 30, 0, 0, 0, 0, time.UTC), time.Date(2000, 6, 30, 0, 0, 0, 0, time.UTC)),
+			icma:     dr.ActActICMA{Frequency: 2, Anchor: datePtr(time.Date(2000, 1, 30, 0, 0, 0, 0, time.UTC))},
+			wantDays: 152,
+			wantISDA: 0.41530054644808745, // 152/366
+			wantICMA: 0.4175824175824176,  // 152/(2*182)
+		},
+		{
+			name:     "long final period, end of month coupons",
+			dr:       accrual(time.Date(1999, 11, 30, 0, 0, 0, 0, time.UTC), time.Date(2000, 4, 30, 0, 0, 0, 0, time.UTC)),
+			icma:     dr.ActActICMA{Frequency: 4, Anchor: datePtr(time.Date(2000, 5, 31, 0, 0, 0, 0, time.UTC))},
+			wantDays: 152,
+			wantISDA: 0.4155400853357287, // 32/365 + 120/366
+			wantICMA: 0.4157608695652174, // 91/(4*91) + 61/(4*92)
+		},
+		{
+			name:     "zero range",
+			dr:       dr.DateRange{},
+			icma:     dr.ActActICMA{Frequency: 2},
+			wantDays: 0,
+			wantISDA: 0,
+			wantICMA: 0,
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			if got := (dr.ActActISDA{}).DayCount(c.dr); got != c.wantDays {
+				t.Errorf("ActActISDA.DayCount(%v) = %v, want %v", c.dr, got, c.wantDays)
+			}
+			if got := (dr.ActActISDA{}).YearFraction(c.dr); math.Abs(got-c.wantISDA) > 1e-12 {
+				t.Errorf("ActActISDA.YearFraction(%v) = %v, want %v", c.dr, got, c.wantISDA)
+			}
+			if got := c.icma.DayCount(c.dr); got != c.wantDays {
+				t.Errorf("%+v.DayCount(%v) = %v, want %v", c.icma, c.dr, got, c.wantDays)
+			}
+			if got := c.icma.YearFraction(c.dr); math.Abs(got-c.wantICMA) > 1e-12 {
+				t.Errorf("%+v.YearFraction(%v) = %v, want %v", c.icma, c.dr, got, c.wantICMA)
+			}
+		})
+	}
+}
+
+// test dr.Act360 and dr.Act365Fixed
+func TestDayCountConventionActFixed(t *testing.T) {
+	cases := []struct {
+		name     string
+		dr       dr.DateRange
+		want360  float64
+		want365F float64
+	}{
+		{
+			name:     "half year",
+			dr:       accrual(time.Date(2003, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2004, 5, 1, 0, 0, 0, 0, time.UTC)),
+			want360:  182.0 / 360,
+			want365F: 182.0 / 365,
+		},
+		{
+			name:     "leap year",
+			dr:       accrual(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+			want360:  366.0 / 360,
+			want365F: 366.0 / 365,
+		},
+		{
+			name:     "zero range",
+			dr:       dr.DateRange{},
+			want360:  0,
+			want365F: 0,
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			if got := (dr.Act360{}).YearFraction(c.dr); got != c.want360 {
+				t.Errorf("Act360.YearFraction(%v) = %v, want %v", c.dr, got, c.want360)
+			}
+			if got := (dr.Act365Fixed{}).YearFraction(c.dr); got != c.want365F {
+				t.Errorf("Act365Fixed.YearFraction(%v) = %v, want %v", c.dr, got, c.want365F)
+			}
+		})
+	}
+}
+
+// test the 30/360 family of dr.DayCountConvention, covering the end of February
+// and 31st adjustments of the ISDA 2006 definitions, section 4.16
+func TestDayCountConventionThirty360(t *testing.T) {
+	cases := []struct {
+		name string
+		conv dr.DayCountConvention
+		dr   dr.DateRange
+		want int
+	}{
+		{
+			name: "30U/360 end of August to end of February",
+			conv: dr.Thirty360US{},
+			dr:   accrual(time.Date(2007, 8, 31, 0, 0, 0, 0, time.UTC), time.Date(2008, 2, 29, 0, 0, 0, 0, time.UTC)),
+			want: 179,
+		},
+		{
+			name: "30U/360 end of February to end of August",
+			conv: dr.Thirty360US{},
+			dr:   accrual(time.Date(2008, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2008, 8, 31, 0, 0, 0, 0, time.UTC)),
+			want: 182,
+		},
+		{
+			name: "30U/360 end of month, end of February to end of August",
+			conv: dr.Thirty360US{EndOfMonth: true},
+			dr:   accrual(time.Date(2008, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2008, 8, 31, 0, 0, 0, 0, time.UTC)),
+			want: 180,
+		},
+		{
+			name: "30U/360 end of month, end of February to end of February",
+			conv: dr.Thirty360US{EndOfMonth: true},
+			dr:   accrual(time.Date(2007, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2008, 2, 29, 0, 0, 0, 0, time.UTC)),
+			want: 360,
+		},
+		{
+			name: "30U/360 31st to 31st",
+			conv: dr.Thirty360US{},
+			dr:   accrual(time.Date(2007, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2007, 3, 31, 0, 0, 0, 0, time.UTC)),
+			want: 60,
+		},
+		{
+			name: "30U/360 15th to 31st",
+			conv: dr.Thirty360US{},
+			dr:   accrual(time.Date(2007, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2007, 3, 31, 0, 0, 0, 0, time.UTC)),
+			want: 76,
+		},
+		{
+			name: "30E/360 end of August to end of February",
+			conv: dr.Thirty360E{},
+			dr:   accrual(time.Date(2007, 8, 31, 0, 0, 0, 0, time.UTC), time.Date(2008, 2, 29, 0, 0, 0, 0, time.UTC)),
+			want: 179,
+		},
+		{
+			name: "30E/360 15th to 31st",
+			conv: dr.Thirty360E{},
+			dr:   accrual(time.Date(2007, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2007, 3, 31, 0, 0, 0, 0, time.UTC)),
+			want: 75,
+		},
+		{
+			name: "30E/360 ISDA end of August to end of February",
+			conv: dr.Thirty360EISDA{},
+			dr:   accrual(time.Date(2007, 8, 31, 0, 0, 0, 0, time.UTC), time.Date(2008, 2, 29, 0, 0, 0, 0, time.UTC)),
+			want: 180,
+		},
+		{
+			name: "30E/360 ISDA end of August to end of February maturity",
+			conv: dr.Thirty360EISDA{Maturity: datePtr(time.Date(2008, 2, 29, 0, 0, 0, 0, time.UTC))},
+			dr:   accrual(time.Date(2007, 8, 31, 0, 0, 0, 0, time.UTC), time.Date(2008, 2, 29, 0, 0, 0, 0, time.UTC)),
+			want: 179,
+		},
+		{
+			name: "30E/360 ISDA end of February to end of August",
+			conv: dr.Thirty360EISDA{},
+			dr:   accrual(time.Date(2007, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2007, 8, 31, 0, 0, 0, 0, time.UTC)),
+			want: 180,
+		},
+		{
+			name: "zero range",
+			conv: dr.Thirty360US{},
+			dr:   dr.DateRange{},
+			want: 0,
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.conv.DayCount(c.dr); got != c.want {
+				t.Errorf("%T.DayCount(%v) = %v, want %v", c.conv, c.dr, got, c.want)
+			}
+			if got, want := c.conv.YearFraction(c.dr), float64(c.want)/360; got != want {
+				t.Errorf("%T.YearFraction(%v) = %v, want %v", c.conv, c.dr, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package daterange
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Diff returns the dates that are in new but not in old, and the dates that are
+// in old but not in new.
+func Diff(old, new DateRanges) (added, removed DateRanges) {
+	return new.Difference(old), old.Difference(new)
+}
+
+// Patch is the difference between two versions of a DateRanges.
+//
+// The text form of a Patch is a space separated list of ISO 8601 date intervals,
+// prefixed by "+" for added and "-" for removed dates, for example
+//
+//	+2024-01-01/2024-01-05 +2024-02-01 -2024-03-10/2024-03-12
+//
+// A single date is written without the interval and unbounded ends are written
+// as -inf and +inf, for example +2024-06-01/+inf. A Patch can be used directly
+// with encoding/json and other packages using encoding.TextMarshaler.
+type Patch struct {
+	Added   DateRanges
+	Removed DateRanges
+}
+
+// NewPatch returns the Patch that changes old into new.
+func NewPatch(old, new DateRanges) Patch {
+	added, removed := Diff(old, new)
+	return Patch{
+		Added:   added,
+		Removed: removed,
+	}
+}
+
+// IsZero returns true if the patch does not change anything
+func (p Patch) IsZero() bool {
+	return p.Added.IsZero() && p.Removed.IsZero()
+}
+
+// Apply returns a new collection with the removed dates of the patch removed
+// from drs and then the added dates added. drs is not modified.
+func (p Patch) Apply(drs DateRanges) DateRanges {
+	patched := drs.Difference(p.Removed)
+	patched.Append(p.Added.dr...)
+	return patched
+}
+
+// String returns the text form of the patch.
+func (p Patch) String() string {
+	text, _ := p.MarshalText()
+	return string(text)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p Patch) MarshalText() ([]byte, error) {
+	entries := make([]string, 0, p.Added.Len()+p.Removed.Len())
+	for _, dr := range p.Added.dr {
+		entries = append(entries, "+"+formatPatchRange(dr))
+	}
+	for _, dr := range p.Removed.dr {
+		entries = append(entries, "-"+formatPatchRange(dr))
+	}
+	return []byte(strings.Join(entries, " ")), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *Patch) UnmarshalText(text []byte) error {
+	added := []DateRange{}
+	removed := []DateRange{}
+	for _, entry := range strings.Fields(string(text)) {
+		if len(entry) < 2 || (entry[0] != '+' && entry[0] != '-') {
+			return fmt.Errorf("patch: malformed entry %q", entry)
+		}
+		dr, err := parsePatchRange(entry[1:])
+		if err != nil {
+			return err
+		}
+		if entry[0] == '+' {
+			added = append(added, dr)
+		} else {
+			removed = append(removed, dr)
+		}
+	}
+	p.Added = NewDateRanges(added...)
+	p.Removed = NewDateRanges(removed...)
+	return nil
+}
+
+// formatPatchRange formats a DateRange as an ISO 8601 interval, or a single date.
+func formatPatchRange(dr DateRange) string {
+	if dr.from.Equal(dr.to) {
+		return formatBound(dr.from)
+	}
+	return formatBound(dr.from) + "/" + formatBound(dr.to)
+}
+
+// parsePatchRange parses an ISO 8601 interval of dates, or a single date.
+func parsePatchRange(value string) (DateRange, error) {
+	first, second, isInterval := strings.Cut(value, "/")
+	from, err := parseBound(first)
+	if err != nil {
+		return DateRange{}, fmt.Errorf("patch: malformed date %q", first)
+	}
+	to := from
+	if isInterval {
+		if to, err = parseBound(second); err != nil {
+			return DateRange{}, fmt.Errorf("patch: malformed date %q", second)
+		}
+	}
+	if isPosInf(from) || isNegInf(to) {
+		return DateRange{}, fmt.Errorf("patch: malformed interval %q", value)
+	}
+	if to.Before(from) {
+		return DateRange{}, fmt.Errorf("patch: interval %q ends before it starts", value)
+	}
+	return DateRange{from: from, to: to, nonEmpty: true}, nil
+}
@@ -0,0 +1,221 @@
+package daterange_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// test dr.Diff
+func TestDiff(t *testing.T) {
+	cases := []struct {
+		name        string
+		old         []dr.DateRange
+		new         []dr.DateRange
+		wantAdded   []dr.DateRange
+		wantRemoved []dr.DateRange
+	}{
+		{
+			name:        "both empty",
+			old:         []dr.DateRange{},
+			new:         []dr.DateRange{},
+			wantAdded:   []dr.DateRange{},
+			wantRemoved: []dr.DateRange{},
+		},
+		{
+			name:        "equal",
+			old:         []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+			new:         []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+			wantAdded:   []dr.DateRange{},
+			wantRemoved: []dr.DateRange{},
+		},
+		{
+			name:        "extended and shortened",
+			old:         []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+			new:         []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC))},
+			wantAdded:   []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC))},
+			wantRemoved: []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name: "hole punched and gap filled",
+			old: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 22, 0, 0, 0, 0, time.UTC)),
+			},
+			new: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 22, 0, 0, 0, 0, time.UTC)),
+			},
+			wantAdded:   []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC))},
+			wantRemoved: []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			old, new := dr.NewDateRanges(c.old...), dr.NewDateRanges(c.new...)
+			added, removed := dr.Diff(old, new)
+			if !reflect.DeepEqual(added.ToSlice(), c.wantAdded) {
+				t.Errorf("Diff(%v, %v) added = %v, want %v", c.old, c.new, added, c.wantAdded)
+			}
+			if !reflect.DeepEqual(removed.ToSlice(), c.wantRemoved) {
+				t.Errorf("Diff(%v, %v) removed = %v, want %v", c.old, c.new, removed, c.wantRemoved)
+			}
+			// applying the patch to old gives new
+			patched := dr.NewPatch(old, new).Apply(old)
+			if !patched.Equal(new) {
+				t.Errorf("NewPatch(%v, %v).Apply(%v) = %v, want %v", c.old, c.new, c.old, patched, c.new)
+			}
+		})
+	}
+}
+
+// test dr.Patch.MarshalText
+func TestPatchMarshalText(t *testing.T) {
+	cases := []struct {
+		name  string
+		patch dr.Patch
+		want  string
+	}{
+		{
+			name:  "empty",
+			patch: dr.Patch{},
+			want:  "",
+		},
+		{
+			name: "added and removed",
+			patch: dr.Patch{
+				Added: dr.NewDateRanges(
+					dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+					dr.NewDateRange(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+				),
+				Removed: dr.NewDateRanges(
+					dr.NewDateRange(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC)),
+				),
+			},
+			want: "+2024-01-01/2024-01-05 +2024-02-01 -2024-03-10/2024-03-12",
+		},
+		{
+			name: "unbounded",
+			patch: dr.Patch{
+				Added:   dr.NewDateRanges(dr.NewDateRangeFrom(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))),
+				Removed: dr.NewDateRanges(dr.NewDateRangeUntil(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))),
+			},
+			want: "+2024-06-01/+inf --inf/2023-12-31",
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got, err := c.patch.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText() error = %v", err)
+			}
+			if string(got) != c.want {
+				t.Errorf("MarshalText() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.Patch.UnmarshalText
+func TestPatchUnmarshalText(t *testing.T) {
+	cases := []struct {
+		name        string
+		text        string
+		wantAdded   []dr.DateRange
+		wantRemoved []dr.DateRange
+		wantErr     bool
+	}{
+		{
+			name:        "empty",
+			text:        "",
+			wantAdded:   []dr.DateRange{},
+			wantRemoved: []dr.DateRange{},
+		},
+		{
+			name: "unsorted and overlapping",
+			text: " -2024-03-10/2024-03-12  +2024-02-01\n+2024-01-01/2024-01-05 +2024-01-04/2024-01-06",
+			wantAdded: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			wantRemoved: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:        "unbounded",
+			text:        "--inf/2023-12-31 +2024-06-01/+inf",
+			wantAdded:   []dr.DateRange{dr.NewDateRangeFrom(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))},
+			wantRemoved: []dr.DateRange{dr.NewDateRangeUntil(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:    "infinite single date",
+			text:    "+-inf",
+			wantErr: true,
+		},
+		{
+			name:    "positive infinite start",
+			text:    "++inf/+inf",
+			wantErr: true,
+		},
+		{
+			name:    "missing sign",
+			text:    "2024-01-01",
+			wantErr: true,
+		},
+		{
+			name:    "malformed date",
+			text:    "+2024-01-01/2024-13-01",
+			wantErr: true,
+		},
+		{
+			name:    "reversed interval",
+			text:    "-2024-01-05/2024-01-01",
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			var p dr.Patch
+			err := p.UnmarshalText([]byte(c.text))
+			if (err != nil) != c.wantErr {
+				t.Fatalf("UnmarshalText(%q) error = %v, wantErr %v", c.text, err, c.wantErr)
+			}
+			if c.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(p.Added.ToSlice(), c.wantAdded) {
+				t.Errorf("UnmarshalText(%q) added = %v, want %v", c.text, p.Added, c.wantAdded)
+			}
+			if !reflect.DeepEqual(p.Removed.ToSlice(), c.wantRemoved) {
+				t.Errorf("UnmarshalText(%q) removed = %v, want %v", c.text, p.Removed, c.wantRemoved)
+			}
+		})
+	}
+}
+
+// test JSON round trip of dr.Patch
+func TestPatchJSON(t *testing.T) {
+	old := dr.NewDateRanges(dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)))
+	new := dr.NewDateRanges(dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)))
+	data, err := json.Marshal(dr.NewPatch(old, new))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `"+2024-01-11/2024-01-15 -2024-01-01/2024-01-04"`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+	var p dr.Patch
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("json.Unmarshal(%s) error = %v", data, err)
+	}
+	if patched := p.Apply(old); !patched.Equal(new) {
+		t.Errorf("Apply(%v) = %v, want %v", old, patched, new)
+	}
+}
@@ -1,7 +1,9 @@
 package daterange_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	daterange "github.com/felixenescu/date-range"
@@ -43,12 +45,23 @@ func ExampleDateRange_String() {
 }
 
 func ExampleDateRange_IsZero() {
-	// Create a new DateRange
-	dr := daterange.NewDateRange(time.Time{}, time.Time{})
+	// The zero value is the empty range
+	dr := daterange.DateRange{}
 	fmt.Println(dr.IsZero())
 	// Output: true
 }
 
+func ExampleDateRange_IsEmpty() {
+	// The date 0001-01-01 is a real date, no overlap gives the empty range
+	first := daterange.NewDateRange(time.Time{}, time.Time{})
+	other := daterange.NewDateRange(time.Date(2024, 1, 26, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 28, 0, 0, 0, 0, time.UTC))
+	fmt.Println(first.IsEmpty(), first.Days())
+	fmt.Println(first.Intersection(other).IsEmpty(), first.Intersection(other))
+	// Output:
+	// false 1
+	// true {}
+}
+
 func ExampleDateRange_Contains() {
 	// Create a new DateRange
 	dr := daterange.NewDateRange(time.Date(2024, 1, 26, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 28, 0, 0, 0, 0, time.UTC))
@@ -223,3 +236,459 @@ func ExampleDateRanges_SplitInclusive() {
 	fmt.Println(before.String(), after.String())
 	// Output: [{2024-01-01 - 2024-01-03} {2024-01-15 - 2024-01-20}] [{2024-01-20 - 2024-01-27}]
 }
+
+func ExampleRecurrence_Expand() {
+	// Every second Tuesday of the month, for 3 days
+	r := daterange.Recurrence{
+		Start:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Freq:     daterange.Monthly,
+		ByDay:    []daterange.WeekdayNum{{Weekday: time.Tuesday, N: 2}},
+		Duration: 3,
+	}
+	window := daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC))
+	fmt.Println(r.Expand(window).String())
+	// Output: [{2024-01-09 - 2024-01-11} {2024-02-13 - 2024-02-15} {2024-03-12 - 2024-03-14}]
+}
+
+func ExampleICalEncoder_Encode() {
+	// Create a new DateRanges
+	drs := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 26, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 28, 0, 0, 0, 0, time.UTC)),
+	)
+	var sb strings.Builder
+	enc := daterange.NewICalEncoder(&sb)
+	enc.SetTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	enc.SetSummary("Leave")
+	_ = enc.Encode(drs)
+	// iCalendar lines end with CRLF
+	fmt.Print(strings.ReplaceAll(sb.String(), "\r\n", "\n"))
+	// Output:
+	// BEGIN:VCALENDAR
+	// VERSION:2.0
+	// PRODID:-//felixenescu//date-range//EN
+	// CALSCALE:GREGORIAN
+	// BEGIN:VEVENT
+	// UID:20240126-20240128@date-range
+	// DTSTAMP:20240101T000000Z
+	// DTSTART;VALUE=DATE:20240126
+	// DTEND;VALUE=DATE:20240129
+	// SUMMARY:Leave
+	// END:VEVENT
+	// END:VCALENDAR
+}
+
+func ExampleICalDecoder_Decode() {
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20240126\r\n" +
+		"DTEND;VALUE=DATE:20240129\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	drs, err := daterange.NewICalDecoder(strings.NewReader(ics)).Decode()
+	fmt.Println(drs.String(), err)
+	// Output: [{2024-01-26 - 2024-01-28}] <nil>
+}
+
+func ExampleDateRange_SplitBy() {
+	// Create a new DateRange
+	dr := daterange.NewDateRange(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC))
+	fmt.Println(dr.SplitBy(daterange.Month))
+	// Output: [{2024-01-15 - 2024-01-31} {2024-02-01 - 2024-02-29} {2024-03-01 - 2024-03-10}]
+}
+
+func ExampleDateRange_SplitEvery() {
+	// Create a new DateRange
+	dr := daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))
+	fmt.Println(dr.SplitEvery(4))
+	// Output: [{2024-01-01 - 2024-01-04} {2024-01-05 - 2024-01-08} {2024-01-09 - 2024-01-10}]
+}
+
+func ExampleNewPeriod() {
+	// Create the ISO week containing a date
+	dr := daterange.NewPeriod(time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC), daterange.ISOWeek)
+	fmt.Println(dr.String())
+	// Output: {2024-02-12 - 2024-02-18}
+}
+
+func ExampleDateRange_AlignOutward() {
+	// Create a new DateRange
+	dr := daterange.NewDateRange(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC))
+	fmt.Println(dr.AlignOutward(daterange.Month).String())
+	// Output: {2024-01-01 - 2024-03-31}
+}
+
+func ExampleDateRange_AlignInward() {
+	// Create a new DateRange
+	dr := daterange.NewDateRange(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC))
+	fmt.Println(dr.AlignInward(daterange.Month).String())
+	// Output: {2024-02-01 - 2024-02-29}
+}
+
+func ExampleDateRange_Windows() {
+	// Create a new DateRange
+	dr := daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))
+	// 3-day windows sliding by one day
+	dr.Windows(3, daterange.WindowOptions{Step: 1})(func(w daterange.DateRange) bool {
+		fmt.Println(w.String())
+		return true
+	})
+	// Output:
+	// {2024-01-01 - 2024-01-03}
+	// {2024-01-02 - 2024-01-04}
+	// {2024-01-03 - 2024-01-05}
+}
+
+func ExampleDateRange_Days() {
+	// Create a new DateRange
+	dr := daterange.NewDateRange(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC))
+	fmt.Println(dr.Days())
+	// Output: 29
+}
+
+func ExampleFromDates() {
+	// Create a new DateRanges from individual dates
+	drs := daterange.FromDates(
+		time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+	)
+	fmt.Println(drs.String())
+	// Output: [{2024-01-01 - 2024-01-03} {2024-01-07 - 2024-01-07}]
+}
+
+func ExampleDateRanges_LongestStreak() {
+	// Create a new DateRanges
+	drs := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+		daterange.NewDateRange(time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+	)
+	fmt.Println(drs.LongestStreak().String())
+	// Output: {2024-01-07 - 2024-01-12}
+}
+
+func ExampleDateRanges_Difference() {
+	// Create a new DateRanges
+	drs := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+	)
+	other := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+	)
+	fmt.Println(drs.Difference(other).String())
+	// Output: [{2024-01-01 - 2024-01-09} {2024-01-21 - 2024-01-31}]
+}
+
+func ExampleDateRanges_Complement() {
+	// Create a new DateRanges
+	drs := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+	)
+	fmt.Println(drs.Complement(daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))).String())
+	// Output: [{2024-01-01 - 2024-01-09} {2024-01-21 - 2024-01-31}]
+}
+
+func ExamplePlanBackfill() {
+	expected := daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+	present := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+	)
+	fmt.Println(daterange.PlanBackfill(expected, present, 5, daterange.NewestFirst))
+	// Output: [{2024-01-27 - 2024-01-31} {2024-01-22 - 2024-01-26} {2024-01-21 - 2024-01-21}]
+}
+
+func ExampleDateRanges_MergeWithin() {
+	// Create a new DateRanges
+	drs := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+		daterange.NewDateRange(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)),
+	)
+	fmt.Println(drs.MergeWithin(2).String())
+	// Output: [{2024-01-01 - 2024-01-07}]
+}
+
+func ExampleDateRanges_Clip() {
+	// Create a new DateRanges
+	drs := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+		daterange.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+	)
+	fmt.Println(drs.Clip(daterange.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 25, 0, 0, 0, 0, time.UTC))).String())
+	// Output: [{2024-01-05 - 2024-01-10} {2024-01-20 - 2024-01-25}]
+}
+
+func ExampleReduce() {
+	// Create a new DateRanges
+	drs := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+		daterange.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+	)
+	total := daterange.Reduce(drs, 0, func(days int, dr daterange.DateRange) int {
+		return days + dr.Days()
+	})
+	fmt.Println(total)
+	// Output: 22
+}
+
+func ExampleDateRanges_NthDate() {
+	// Trading days of the first half of January 2024
+	drs := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+		daterange.NewDateRange(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+	)
+	date, ok := drs.NthDate(5)
+	fmt.Println(date.Format("2006-01-02"), ok)
+	// Output: 2024-01-08 true
+}
+
+func ExampleDateRanges_Rank() {
+	// Trading days of the first half of January 2024
+	drs := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+		daterange.NewDateRange(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+	)
+	fmt.Println(drs.Rank(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)))
+	// Output: 7 true
+}
+
+func ExampleDateRanges_Remove() {
+	// Create a new DateRanges
+	drs := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+	)
+	drs.Remove(daterange.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(drs.String())
+	// Output: [{2024-01-01 - 2024-01-09} {2024-01-21 - 2024-01-31}]
+}
+
+func ExampleDateRanges_RemoveDate() {
+	// Create a new DateRanges
+	drs := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+	)
+	drs.RemoveDate(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))
+	fmt.Println(drs.String())
+	// Output: [{2024-01-01 - 2024-01-02} {2024-01-04 - 2024-01-05}]
+}
+
+func ExampleSyncDateRanges_ReserveIfFree() {
+	// Create a new SyncDateRanges holding the booked dates
+	booked := daterange.NewSyncDateRanges(
+		daterange.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC)),
+	)
+	fmt.Println(booked.ReserveIfFree(daterange.NewDateRange(time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC))))
+	fmt.Println(booked.ReserveIfFree(daterange.NewDateRange(time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC))))
+	fmt.Println(booked.String())
+	// Output:
+	// false
+	// true
+	// [{2024-07-01 - 2024-07-08}]
+}
+
+func ExamplePersistentDateRanges_Remove() {
+	// Create a new PersistentDateRanges
+	v1 := daterange.NewPersistentDateRanges(
+		daterange.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)),
+	)
+	// Every change returns a new version
+	v2 := v1.Remove(daterange.NewDateRange(time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(v1.String())
+	fmt.Println(v2.String())
+	// Output:
+	// [{2024-07-01 - 2024-07-31}]
+	// [{2024-07-01 - 2024-07-09} {2024-07-13 - 2024-07-31}]
+}
+
+func ExampleDiff() {
+	// Two versions of a collection
+	old := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+	)
+	new := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)),
+	)
+	added, removed := daterange.Diff(old, new)
+	fmt.Println("Added:", added.String())
+	fmt.Println("Removed:", removed.String())
+	// Output:
+	// Added: [{2024-01-11 - 2024-01-15}]
+	// Removed: [{2024-01-01 - 2024-01-04}]
+}
+
+func ExamplePatch_Apply() {
+	// Parse a patch from its text form
+	var patch daterange.Patch
+	if err := patch.UnmarshalText([]byte("+2024-01-11/2024-01-15 -2024-01-01/2024-01-04")); err != nil {
+		panic(err)
+	}
+	drs := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+	)
+	patched := patch.Apply(drs)
+	fmt.Println(patched.String())
+	// Output: [{2024-01-05 - 2024-01-15}]
+}
+
+func ExampleActActISDA_YearFraction() {
+	// Accrual from 2003-11-01 up to, but excluding, 2004-05-01
+	period := daterange.NewDateRange(time.Date(2003, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2004, 4, 30, 0, 0, 0, 0, time.UTC))
+	conventions := []daterange.DayCountConvention{
+		daterange.Act360{},
+		daterange.Act365Fixed{},
+		daterange.ActActISDA{},
+		daterange.ActActICMA{Frequency: 2},
+		daterange.Thirty360E{},
+	}
+	for _, conv := range conventions {
+		fmt.Printf("%T: %d days, %.11f\n", conv, conv.DayCount(period), conv.YearFraction(period))
+	}
+	// Output:
+	// daterange.Act360: 182 days, 0.50555555556
+	// daterange.Act365Fixed: 182 days, 0.49863013699
+	// daterange.ActActISDA: 182 days, 0.49772438057
+	// daterange.ActActICMA: 182 days, 0.50000000000
+	// daterange.Thirty360E: 180 days, 0.50000000000
+}
+
+func ExampleBillingCycle_Periods() {
+	// A subscription renewing on the 31st of every month
+	cycle := daterange.MonthlyCycle(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+	window := daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+	cycle.Periods(window)(func(period daterange.DateRange) bool {
+		fmt.Println(period)
+		return true
+	})
+	// Output:
+	// {2024-01-31 - 2024-02-28}
+	// {2024-02-29 - 2024-03-30}
+	// {2024-03-31 - 2024-04-29}
+}
+
+func ExampleProrate() {
+	// A plan of 9.99 changes on January 11th, split the charge by days used
+	shares := daterange.Prorate(999,
+		daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+		daterange.NewDateRange(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+	)
+	fmt.Println(shares)
+	// Output: [322 677]
+}
+
+func ExampleDateRanges_FirstFreeSlot() {
+	// The dates a room is booked
+	booked := daterange.NewDateRanges(
+		daterange.NewDateRange(time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC)),
+		daterange.NewDateRange(time.Date(2024, 7, 9, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC)),
+	)
+	// The first week starting on a Saturday in July
+	slot, ok := booked.FirstFreeSlot(daterange.SlotQuery{
+		Days:    7,
+		Bounds:  daterange.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)),
+		CheckIn: []time.Weekday{time.Saturday},
+	})
+	fmt.Println(slot, ok)
+	// Output: {2024-07-13 - 2024-07-19} true
+}
+
+func ExampleStayRules_Validate() {
+	// Minimum 3 nights and arrival only on Saturdays in July
+	rules := daterange.StayRules{
+		{
+			Season:      daterange.NewDateRanges(daterange.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC))),
+			MinNights:   3,
+			ArrivalDays: []time.Weekday{time.Saturday},
+		},
+	}
+	// Two nights arriving on Monday, July 8th
+	stay := daterange.NewDateRange(time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 9, 0, 0, 0, 0, time.UTC))
+	for _, v := range rules.Validate(stay) {
+		fmt.Println(v.Error())
+	}
+	// Output:
+	// stay arriving 2024-07-08: 2 nights, rule 0 requires at least 3
+	// stay arriving 2024-07-08: rule 0 does not allow arrivals on Monday
+}
+
+func ExampleRateTable_Quote() {
+	// A base rate, a high season and a promotion, in cents
+	table := daterange.RateTable{
+		{Range: daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)), Priority: 0, Rate: 10000},
+		{Range: daterange.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 8, 31, 0, 0, 0, 0, time.UTC)), Priority: 1, Rate: 15000},
+		{Range: daterange.NewDateRange(time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)), Priority: 2, Rate: 9000},
+	}
+	// Nights of June 30th to July 2nd, departing on July 3rd
+	quote := table.Quote(daterange.NewDateRange(time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)))
+	for _, night := range quote.Nights {
+		fmt.Println(night.Date.Format("2006-01-02"), night.Rate)
+	}
+	fmt.Println("Total:", quote.Total)
+	// Output:
+	// 2024-06-30 10000
+	// 2024-07-01 15000
+	// 2024-07-02 9000
+	// Total: 34000
+}
+
+func ExampleMinResources() {
+	// Requested bookings
+	requests := []daterange.DateRange{
+		daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+		daterange.NewDateRange(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)),
+		daterange.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)),
+	}
+	fmt.Println(daterange.MaxNonOverlapping(requests))
+	fmt.Println(daterange.MinResources(requests))
+	// Output:
+	// [1 2]
+	// 2 [0 1 1]
+}
+
+func ExampleConflicts() {
+	// Bookings of the same room
+	bookings := []daterange.DateRange{
+		daterange.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+		daterange.NewDateRange(time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)),
+		daterange.NewDateRange(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+	}
+	for _, c := range daterange.Conflicts(bookings) {
+		fmt.Println(c.I, c.J, c.Overlap)
+	}
+	// Output:
+	// 0 2 {2024-01-08 - 2024-01-10}
+	// 1 2 {2024-01-12 - 2024-01-12}
+}
+
+func ExampleCalendar() {
+	// Absences per employee
+	absences := daterange.NewCalendar[string]()
+	absences.Add("ann", daterange.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)))
+	absences.Add("bob", daterange.NewDateRange(time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC)))
+	july := daterange.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC))
+
+	fmt.Println(absences.FreeOn(time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC)))
+	fmt.Println(absences.AllFree(july))
+	fmt.Println(absences.FreeAtLeast(1, july))
+	// Output:
+	// [bob]
+	// [{2024-07-13 - 2024-07-31}]
+	// [{2024-07-01 - 2024-07-04} {2024-07-11 - 2024-07-31}]
+}
+
+func ExampleNewDateRangeFrom() {
+	// A contract with no end date
+	contract := daterange.NewDateRangeFrom(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	fmt.Println(contract)
+	fmt.Println(contract.Contains(time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	outside := daterange.NewUnboundedDateRange().Difference(contract)
+	fmt.Println(outside)
+
+	data, _ := json.Marshal(contract)
+	fmt.Println(string(data))
+	// Output:
+	// {2024-06-01 - +inf}
+	// true
+	// [{-inf - 2024-05-31}]
+	// {"from":"2024-06-01","to":"+inf"}
+}
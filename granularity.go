@@ -0,0 +1,65 @@
+package daterange
+
+import "time"
+
+// Granularity is a calendar period used to split and align date ranges.
+type Granularity int
+
+const (
+	// Day is a single calendar day.
+	Day Granularity = iota + 1
+	// Month is a calendar month.
+	Month
+	// Quarter is a calendar quarter, starting in January, April, July or October.
+	Quarter
+	// Year is a calendar year.
+	Year
+	// week is a week starting on Sunday, WeekStarting adds the start weekday.
+	week
+
+	// ISOWeek is an ISO 8601 week, starting on Monday.
+	ISOWeek = week + Granularity(time.Monday)
+)
+
+// WeekStarting returns the week Granularity with weeks starting on the given weekday.
+func WeekStarting(weekday time.Weekday) Granularity {
+	return week + Granularity(weekday)
+}
+
+// valid returns true if g is a known Granularity.
+func (g Granularity) valid() bool {
+	return g >= Day && g <= week+Granularity(time.Saturday)
+}
+
+// periodStart returns the first day of the period containing the given date.
+// The date must already be truncated to midnight UTC.
+func (g Granularity) periodStart(date time.Time) time.Time {
+	switch {
+	case g == Month:
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case g == Quarter:
+		month := (date.Month()-1)/3*3 + 1
+		return time.Date(date.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+	case g == Year:
+		return time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	case g >= week:
+		weekStart := time.Weekday(g - week)
+		return date.AddDate(0, 0, -((int(date.Weekday()) - int(weekStart) + 7) % 7))
+	}
+	return date
+}
+
+// nextPeriod returns the first day of the period following the one starting on the given date.
+func (g Granularity) nextPeriod(periodStart time.Time) time.Time {
+	switch {
+	case g == Month:
+		return periodStart.AddDate(0, 1, 0)
+	case g == Quarter:
+		return periodStart.AddDate(0, 3, 0)
+	case g == Year:
+		return periodStart.AddDate(1, 0, 0)
+	case g >= week:
+		return periodStart.AddDate(0, 0, 7)
+	}
+	return periodStart.AddDate(0, 0, 1)
+}
@@ -0,0 +1,640 @@
+package daterange
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	icalProdID       = "-//felixenescu//date-range//EN"
+	icalDateFormat   = "20060102"
+	icalStampFormat  = "20060102T150405Z"
+	icalLocalFormat  = "20060102T150405"
+	icalMaxLineBytes = 75
+	icalHorizonYears = 100 // expansion of recurring events without a window
+)
+
+// ICalEncoder writes DateRanges as an RFC 5545 calendar. Every member of the
+// collection is written as an all-day VEVENT, using DTSTART;VALUE=DATE and the
+// exclusive DTEND;VALUE=DATE.
+type ICalEncoder struct {
+	w       io.Writer
+	summary string
+	stamp   time.Time
+}
+
+// NewICalEncoder returns a new encoder that writes to w.
+func NewICalEncoder(w io.Writer) *ICalEncoder {
+	return &ICalEncoder{w: w}
+}
+
+// SetSummary sets the SUMMARY of every written event. No SUMMARY is written if empty.
+func (e *ICalEncoder) SetSummary(summary string) {
+	e.summary = summary
+}
+
+// SetTimestamp sets the DTSTAMP of every written event. The current time is
+// used if the timestamp is zero.
+func (e *ICalEncoder) SetTimestamp(stamp time.Time) {
+	e.stamp = stamp
+}
+
+// Encode writes the collection as a VCALENDAR with one VEVENT per member.
+// Nothing is written and an error is returned if a member is unbounded, as an
+// event needs a start and an end.
+func (e *ICalEncoder) Encode(drs DateRanges) error {
+	for _, dr := range drs.dr {
+		if !dr.IsBounded() {
+			return fmt.Errorf("ical: cannot encode unbounded range %v", dr)
+		}
+	}
+	stamp := e.stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:" + icalProdID,
+		"CALSCALE:GREGORIAN",
+	}
+	for _, dr := range drs.dr {
+		from := dr.from.Format(icalDateFormat)
+		end := dr.to.AddDate(0, 0, 1).Format(icalDateFormat)
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+from+"-"+dr.to.Format(icalDateFormat)+"@date-range",
+			"DTSTAMP:"+stamp.UTC().Format(icalStampFormat),
+			"DTSTART;VALUE=DATE:"+from,
+			"DTEND;VALUE=DATE:"+end,
+		)
+		if e.summary != "" {
+			lines = append(lines, "SUMMARY:"+icalEscape(e.summary))
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var sb strings.Builder
+	for _, line := range lines {
+		sb.WriteString(icalFold(line))
+	}
+	_, err := io.WriteString(e.w, sb.String())
+	return err
+}
+
+// ICalDecoder reads DateRanges from an RFC 5545 calendar.
+type ICalDecoder struct {
+	r      io.Reader
+	window DateRange
+}
+
+// NewICalDecoder returns a new decoder that reads from r.
+func NewICalDecoder(r io.Reader) *ICalDecoder {
+	return &ICalDecoder{r: r}
+}
+
+// SetWindow sets the range in which recurring events are expanded.
+// Occurrences outside of it are skipped. A window with an end is needed to
+// decode events that repeat forever, without COUNT or UNTIL. Without a window,
+// recurring events are expanded for 100 years from their start.
+// As the input may not be trusted, the window should have an end.
+func (d *ICalDecoder) SetWindow(window DateRange) {
+	d.window = window
+}
+
+// Decode reads the whole input and returns the dates covered by all VEVENT
+// components and by all busy periods of the VFREEBUSY components.
+// All-day events use the exclusive DTEND of RFC 5545. For events with a time,
+// every date touched by the event is included, except an end at midnight.
+// Time zones are ignored, the dates are taken as written.
+//
+// Recurring events are expanded from their RRULE, RDATE and EXDATE properties,
+// and an event with a RECURRENCE-ID replaces that occurrence of the event with
+// the same UID. Events with STATUS:CANCELLED are skipped. An error is returned
+// for the RRULE parts that Recurrence does not support, such as BYMONTH.
+func (d *ICalDecoder) Decode() (DateRanges, error) {
+	lines, err := icalUnfold(d.r)
+	if err != nil {
+		return NewDateRanges(), err
+	}
+
+	ranges := []DateRange{}
+	events := []icalEvent{}
+	var components []string
+	var event icalEvent
+	for i, line := range lines {
+		prop, err := parseICalLine(line)
+		if err != nil {
+			return NewDateRanges(), fmt.Errorf("ical: line %d: %w", i+1, err)
+		}
+		switch prop.name {
+		case "BEGIN":
+			components = append(components, strings.ToUpper(prop.value))
+			if strings.EqualFold(prop.value, "VEVENT") {
+				event = icalEvent{props: map[string][]icalProperty{}}
+			}
+			continue
+		case "END":
+			if len(components) == 0 || components[len(components)-1] != strings.ToUpper(prop.value) {
+				return NewDateRanges(), fmt.Errorf("ical: line %d: unexpected END:%s", i+1, prop.value)
+			}
+			components = components[:len(components)-1]
+			if strings.EqualFold(prop.value, "VEVENT") {
+				event.line = i + 1
+				events = append(events, event)
+				event = icalEvent{}
+			}
+			continue
+		}
+		if len(components) == 0 {
+			continue
+		}
+		switch components[len(components)-1] {
+		case "VEVENT":
+			event.props[prop.name] = append(event.props[prop.name], prop)
+		case "VFREEBUSY":
+			if prop.name != "FREEBUSY" || strings.EqualFold(prop.params["FBTYPE"], "FREE") {
+				continue
+			}
+			periods, err := parseICalPeriods(prop.value)
+			if err != nil {
+				return NewDateRanges(), fmt.Errorf("ical: line %d: %w", i+1, err)
+			}
+			ranges = append(ranges, periods...)
+		}
+	}
+	if len(components) > 0 {
+		return NewDateRanges(), fmt.Errorf("ical: missing END:%s", components[len(components)-1])
+	}
+
+	// occurrences replaced by an event with a RECURRENCE-ID, by UID
+	replaced := map[string][]time.Time{}
+	for _, event := range events {
+		if id, ok := event.get("RECURRENCE-ID"); ok {
+			date, _, err := parseICalTime(id.value)
+			if err != nil {
+				return NewDateRanges(), fmt.Errorf("ical: line %d: %w", event.line, err)
+			}
+			uid, _ := event.get("UID")
+			replaced[uid.value] = append(replaced[uid.value], toDateUTC(date))
+		}
+	}
+	for _, event := range events {
+		if status, ok := event.get("STATUS"); ok && strings.EqualFold(status.value, "CANCELLED") {
+			continue
+		}
+		var excluded []time.Time
+		if _, ok := event.get("RECURRENCE-ID"); !ok {
+			uid, _ := event.get("UID")
+			excluded = replaced[uid.value]
+		}
+		occurrences, err := d.eventRanges(event, excluded)
+		if err != nil {
+			return NewDateRanges(), fmt.Errorf("ical: line %d: %w", event.line, err)
+		}
+		ranges = append(ranges, occurrences...)
+	}
+	return NewDateRanges(ranges...), nil
+}
+
+// eventRanges returns the dates covered by all occurrences of a VEVENT,
+// skipping the occurrences starting on an excluded date.
+func (d *ICalDecoder) eventRanges(event icalEvent, excluded []time.Time) ([]DateRange, error) {
+	first, err := icalEventRange(event)
+	if err != nil {
+		return nil, err
+	}
+	for _, exdate := range event.props["EXDATE"] {
+		dates, err := parseICalDates(exdate.value)
+		if err != nil {
+			return nil, err
+		}
+		excluded = append(excluded, dates...)
+	}
+	isExcluded := func(date time.Time) bool {
+		for _, ex := range excluded {
+			if ex.Equal(date) {
+				return true
+			}
+		}
+		return false
+	}
+
+	ranges := []DateRange{}
+	if rrule, ok := event.get("RRULE"); ok {
+		r, err := parseICalRRule(rrule.value)
+		if err != nil {
+			return nil, err
+		}
+		r.Start = first.from
+		r.Duration = first.Days()
+		r.Exclude = excluded
+		window := d.window
+		if window.IsEmpty() {
+			if r.Count < 1 && r.Until == nil {
+				return nil, fmt.Errorf("RRULE without COUNT or UNTIL needs a window with an end")
+			}
+			window = NewDateRange(first.from, addDays(first.from.AddDate(icalHorizonYears, 0, 0), -1))
+		}
+		if window.IsToInf() && r.Count < 1 && r.Until == nil {
+			return nil, fmt.Errorf("RRULE without COUNT or UNTIL needs a window with an end")
+		}
+		expanded := r.Expand(window)
+		ranges = append(ranges, expanded.dr...)
+	} else if !isExcluded(first.from) {
+		ranges = append(ranges, first)
+	}
+
+	for _, rdate := range event.props["RDATE"] {
+		if strings.EqualFold(rdate.params["VALUE"], "PERIOD") {
+			periods, err := parseICalPeriods(rdate.value)
+			if err != nil {
+				return nil, err
+			}
+			for _, period := range periods {
+				if !isExcluded(period.from) {
+					ranges = append(ranges, period)
+				}
+			}
+			continue
+		}
+		dates, err := parseICalDates(rdate.value)
+		if err != nil {
+			return nil, err
+		}
+		for _, date := range dates {
+			if !isExcluded(date) {
+				ranges = append(ranges, NewDateRange(date, date.AddDate(0, 0, first.Days()-1)))
+			}
+		}
+	}
+	return ranges, nil
+}
+
+// icalEvent holds the properties of a VEVENT by name, in the order they were read.
+type icalEvent struct {
+	props map[string][]icalProperty
+	line  int // line of the END:VEVENT
+}
+
+// get returns the first property with the given name.
+func (e icalEvent) get(name string) (icalProperty, bool) {
+	if props := e.props[name]; len(props) > 0 {
+		return props[0], true
+	}
+	return icalProperty{}, false
+}
+
+// icalProperty is a parsed RFC 5545 content line.
+type icalProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// parseICalLine parses a content line of the form name *(";" param) ":" value.
+func parseICalLine(line string) (icalProperty, error) {
+	// find the first colon outside of a quoted parameter value
+	colon := -1
+	quoted := false
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return icalProperty{}, fmt.Errorf("malformed content line %q", line)
+	}
+	parts := strings.Split(line[:colon], ";")
+	prop := icalProperty{
+		name:   strings.ToUpper(parts[0]),
+		params: map[string]string{},
+		value:  line[colon+1:],
+	}
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return prop, nil
+}
+
+// icalEventRange returns the dates covered by a VEVENT from its DTSTART,
+// DTEND and DURATION properties.
+func icalEventRange(event icalEvent) (DateRange, error) {
+	dtstart, ok := event.get("DTSTART")
+	if !ok {
+		return DateRange{}, fmt.Errorf("VEVENT without DTSTART")
+	}
+	start, allDay, err := parseICalTime(dtstart.value)
+	if err != nil {
+		return DateRange{}, err
+	}
+
+	end := start
+	hasEnd := false
+	if dtend, ok := event.get("DTEND"); ok {
+		if end, _, err = parseICalTime(dtend.value); err != nil {
+			return DateRange{}, err
+		}
+		hasEnd = true
+	} else if duration, ok := event.get("DURATION"); ok {
+		days, clock, err := parseICalDuration(duration.value)
+		if err != nil {
+			return DateRange{}, err
+		}
+		end = start.AddDate(0, 0, days).Add(clock)
+		hasEnd = true
+	}
+
+	// the end is exclusive for all-day events and for events ending at midnight
+	if hasEnd && end.After(start) && (allDay || end.Equal(toDateUTC(end))) {
+		end = end.AddDate(0, 0, -1)
+	}
+	if end.Before(start) {
+		end = start
+	}
+	return NewDateRange(start, end), nil
+}
+
+// parseICalPeriods parses a comma separated list of RFC 5545 PERIOD values.
+func parseICalPeriods(value string) ([]DateRange, error) {
+	ranges := []DateRange{}
+	for _, period := range strings.Split(value, ",") {
+		first, second, ok := strings.Cut(period, "/")
+		if !ok {
+			return nil, fmt.Errorf("malformed period %q", period)
+		}
+		start, _, err := parseICalTime(first)
+		if err != nil {
+			return nil, err
+		}
+		var end time.Time
+		if strings.ContainsAny(second, "Pp") {
+			days, clock, err := parseICalDuration(second)
+			if err != nil {
+				return nil, err
+			}
+			end = start.AddDate(0, 0, days).Add(clock)
+		} else if end, _, err = parseICalTime(second); err != nil {
+			return nil, err
+		}
+		if end.After(start) && end.Equal(toDateUTC(end)) {
+			end = end.AddDate(0, 0, -1)
+		}
+		if end.Before(start) {
+			end = start
+		}
+		ranges = append(ranges, NewDateRange(start, end))
+	}
+	return ranges, nil
+}
+
+// parseICalDates parses a comma separated list of DATE or DATE-TIME values
+// into their dates.
+func parseICalDates(value string) ([]time.Time, error) {
+	dates := []time.Time{}
+	for _, item := range strings.Split(value, ",") {
+		t, _, err := parseICalTime(item)
+		if err != nil {
+			return nil, err
+		}
+		dates = append(dates, toDateUTC(t))
+	}
+	return dates, nil
+}
+
+// icalFrequencies maps the FREQ values of an RRULE to a Frequency.
+var icalFrequencies = map[string]Frequency{
+	"DAILY":   Daily,
+	"WEEKLY":  Weekly,
+	"MONTHLY": Monthly,
+	"YEARLY":  Yearly,
+}
+
+// icalWeekdays maps the weekday codes of an RRULE to a time.Weekday.
+var icalWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// parseICalRRule parses an RRULE value into a Recurrence, without its Start,
+// Duration and Exclude. An error is returned for the rule parts that
+// Recurrence does not support.
+func parseICalRRule(value string) (Recurrence, error) {
+	var r Recurrence
+	wkst := "MO"
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return Recurrence{}, fmt.Errorf("malformed RRULE part %q", part)
+		}
+		key, val = strings.ToUpper(key), strings.ToUpper(val)
+		var err error
+		switch key {
+		case "FREQ":
+			if r.Freq, ok = icalFrequencies[val]; !ok {
+				return Recurrence{}, fmt.Errorf("unsupported RRULE frequency %q", val)
+			}
+		case "INTERVAL":
+			if r.Interval, err = strconv.Atoi(val); err == nil && r.Interval < 1 {
+				return Recurrence{}, fmt.Errorf("RRULE value out of range in %q", part)
+			}
+		case "COUNT":
+			if r.Count, err = strconv.Atoi(val); err == nil && r.Count < 1 {
+				return Recurrence{}, fmt.Errorf("RRULE value out of range in %q", part)
+			}
+		case "UNTIL":
+			var until time.Time
+			if until, _, err = parseICalTime(val); err == nil {
+				r.Until = &until
+			}
+		case "BYDAY":
+			for _, item := range strings.Split(val, ",") {
+				if len(item) < 2 {
+					return Recurrence{}, fmt.Errorf("malformed BYDAY %q", item)
+				}
+				weekday, ok := icalWeekdays[item[len(item)-2:]]
+				if !ok {
+					return Recurrence{}, fmt.Errorf("malformed BYDAY %q", item)
+				}
+				n := 0
+				if prefix := item[:len(item)-2]; prefix != "" {
+					if n, err = strconv.Atoi(prefix); err != nil {
+						return Recurrence{}, fmt.Errorf("malformed BYDAY %q", item)
+					}
+				}
+				r.ByDay = append(r.ByDay, WeekdayNum{Weekday: weekday, N: n})
+			}
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseICalInts(val)
+		case "BYSETPOS":
+			r.BySetPos, err = parseICalInts(val)
+		case "WKST":
+			wkst = val
+		default:
+			return Recurrence{}, fmt.Errorf("unsupported RRULE part %q", key)
+		}
+		if err != nil {
+			return Recurrence{}, fmt.Errorf("malformed RRULE part %q", part)
+		}
+	}
+	if r.Freq == 0 {
+		return Recurrence{}, fmt.Errorf("RRULE without FREQ")
+	}
+	if !r.valid() {
+		return Recurrence{}, fmt.Errorf("RRULE value out of range in %q", value)
+	}
+	// Recurrence weeks start on Monday, which only matters for weekly rules
+	// with an interval
+	if wkst != "MO" && r.Freq == Weekly && r.Interval > 1 {
+		return Recurrence{}, fmt.Errorf("unsupported RRULE WKST %q", wkst)
+	}
+	return r, nil
+}
+
+// parseICalInts parses a comma separated list of integers.
+func parseICalInts(value string) ([]int, error) {
+	ints := []int{}
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, err
+		}
+		ints = append(ints, n)
+	}
+	return ints, nil
+}
+
+// parseICalTime parses a DATE or DATE-TIME value. It reports whether the value is a DATE.
+func parseICalTime(value string) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(icalDateFormat, value); err == nil {
+		return t, true, nil
+	}
+	layout := icalLocalFormat
+	if strings.HasSuffix(value, "Z") {
+		layout = icalStampFormat
+	}
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("malformed date %q", value)
+	}
+	return t, false, nil
+}
+
+// parseICalDuration parses a DURATION value into a number of days and a time of day duration.
+func parseICalDuration(value string) (int, time.Duration, error) {
+	malformed := fmt.Errorf("malformed duration %q", value)
+	s := strings.ToUpper(strings.TrimSpace(value))
+	sign := 1
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign = -1
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, 0, malformed
+	}
+	s = s[1:]
+
+	days := 0
+	var clock time.Duration
+	inTime := false
+	number := ""
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			number += string(r)
+			continue
+		case r == 'T' && number == "" && !inTime:
+			inTime = true
+			continue
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, 0, malformed
+		}
+		number = ""
+		switch {
+		case r == 'W' && !inTime:
+			days += 7 * n
+		case r == 'D' && !inTime:
+			days += n
+		case r == 'H' && inTime:
+			clock += time.Duration(n) * time.Hour
+		case r == 'M' && inTime:
+			clock += time.Duration(n) * time.Minute
+		case r == 'S' && inTime:
+			clock += time.Duration(n) * time.Second
+		default:
+			return 0, 0, malformed
+		}
+	}
+	if number != "" {
+		return 0, 0, malformed
+	}
+	return sign * days, time.Duration(sign) * clock, nil
+}
+
+// icalUnfold reads all content lines, joining folded lines.
+func icalUnfold(r io.Reader) ([]string, error) {
+	lines := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// icalFold folds a content line to at most 75 octets per line and terminates it with CRLF.
+func icalFold(line string) string {
+	var sb strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > icalMaxLineBytes {
+			sb.WriteString("\r\n ")
+			width = 1
+		}
+		sb.WriteRune(r)
+		width += size
+	}
+	sb.WriteString("\r\n")
+	return sb.String()
+}
+
+// icalEscape escapes a TEXT value.
+func icalEscape(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
@@ -0,0 +1,388 @@
+package daterange_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// test dr.ICalEncoder.Encode
+func TestICalEncoderEncode(t *testing.T) {
+	cases := []struct {
+		name    string
+		drs     []dr.DateRange
+		summary string
+		want    string
+	}{
+		{
+			name: "empty",
+			drs:  []dr.DateRange{},
+			want: "BEGIN:VCALENDAR\r\n" +
+				"VERSION:2.0\r\n" +
+				"PRODID:-//felixenescu//date-range//EN\r\n" +
+				"CALSCALE:GREGORIAN\r\n" +
+				"END:VCALENDAR\r\n",
+		},
+		{
+			name: "multiple ranges",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+			},
+			summary: "Leave; John, Doe",
+			want: "BEGIN:VCALENDAR\r\n" +
+				"VERSION:2.0\r\n" +
+				"PRODID:-//felixenescu//date-range//EN\r\n" +
+				"CALSCALE:GREGORIAN\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:20240101-20240105@date-range\r\n" +
+				"DTSTAMP:20240315T120000Z\r\n" +
+				"DTSTART;VALUE=DATE:20240101\r\n" +
+				"DTEND;VALUE=DATE:20240106\r\n" +
+				"SUMMARY:Leave\\; John\\, Doe\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:20240229-20240229@date-range\r\n" +
+				"DTSTAMP:20240315T120000Z\r\n" +
+				"DTSTART;VALUE=DATE:20240229\r\n" +
+				"DTEND;VALUE=DATE:20240301\r\n" +
+				"SUMMARY:Leave\\; John\\, Doe\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+		},
+		{
+			name: "long summary is folded",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			summary: strings.Repeat("a", 80),
+			want: "BEGIN:VCALENDAR\r\n" +
+				"VERSION:2.0\r\n" +
+				"PRODID:-//felixenescu//date-range//EN\r\n" +
+				"CALSCALE:GREGORIAN\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:20240101-20240101@date-range\r\n" +
+				"DTSTAMP:20240315T120000Z\r\n" +
+				"DTSTART;VALUE=DATE:20240101\r\n" +
+				"DTEND;VALUE=DATE:20240102\r\n" +
+				"SUMMARY:" + strings.Repeat("a", 67) + "\r\n " + strings.Repeat("a", 13) + "\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := dr.NewICalEncoder(&buf)
+			enc.SetSummary(c.summary)
+			enc.SetTimestamp(time.Date(2024, 3, 15, 14, 0, 0, 0, time.FixedZone("EET", 2*60*60)))
+			if err := enc.Encode(dr.NewDateRanges(c.drs...)); err != nil {
+				t.Fatalf("Encode(%v) error = %v", c.drs, err)
+			}
+			if got := buf.String(); got != c.want {
+				t.Errorf("Encode(%v) = %q, want %q", c.drs, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.ICalEncoder.Encode with an unbounded range
+func TestICalEncoderEncodeUnbounded(t *testing.T) {
+	var buf bytes.Buffer
+	drs := dr.NewDateRanges(
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRangeFrom(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+	)
+	if err := dr.NewICalEncoder(&buf).Encode(drs); err == nil {
+		t.Errorf("Encode(%v) error = nil, want an error", drs)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Encode(%v) wrote %q, want nothing", drs, buf.String())
+	}
+}
+
+// test dr.ICalDecoder.Decode
+func TestICalDecoderDecode(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		want    []dr.DateRange
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  []dr.DateRange{},
+		},
+		{
+			name: "all day events",
+			input: "BEGIN:VCALENDAR\r\n" +
+				"VERSION:2.0\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:20240101\r\n" +
+				"DTEND;VALUE=DATE:20240106\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:20240110\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "timed events, duration and folded lines",
+			input: "BEGIN:VCALENDAR\n" +
+				"BEGIN:VEVENT\n" +
+				"DTSTART;TZID=\"Europe/Bucharest\":20240101T220000\n" +
+				"DTEND;TZID=Europe/Bucharest:20240102T0\n" +
+				" 10000\n" +
+				"SUMMARY:Trip\n" +
+				"END:VEVENT\n" +
+				"BEGIN:VEVENT\n" +
+				"DTSTART:20240110T090000Z\n" +
+				"DTEND:20240112T000000Z\n" +
+				"END:VEVENT\n" +
+				"BEGIN:VEVENT\n" +
+				"DTSTART;VALUE=DATE:20240120\n" +
+				"DURATION:P1W\n" +
+				"END:VEVENT\n" +
+				"END:VCALENDAR\n",
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 26, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "free busy",
+			input: "BEGIN:VCALENDAR\r\n" +
+				"BEGIN:VFREEBUSY\r\n" +
+				"DTSTART:20240101T000000Z\r\n" +
+				"DTEND:20240201T000000Z\r\n" +
+				"FREEBUSY:20240103T080000Z/PT8H,20240105T000000Z/20240107T000000Z\r\n" +
+				"FREEBUSY;FBTYPE=BUSY-TENTATIVE:20240110T230000Z/P1DT2H\r\n" +
+				"FREEBUSY;FBTYPE=FREE:20240120T000000Z/P2D\r\n" +
+				"END:VFREEBUSY\r\n" +
+				"END:VCALENDAR\r\n",
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "weekly recurring event",
+			input: "BEGIN:VCALENDAR\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:20240101\r\n" +
+				"DTEND;VALUE=DATE:20240103\r\n" +
+				"RRULE:FREQ=WEEKLY;COUNT=3\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "exdate and rdate",
+			input: "BEGIN:VCALENDAR\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"DTSTART:20240101T090000Z\r\n" +
+				"DTEND:20240101T170000Z\r\n" +
+				"RRULE:FREQ=DAILY;UNTIL=20240105T090000Z\r\n" +
+				"EXDATE:20240103T090000Z\r\n" +
+				"RDATE;VALUE=DATE:20240110,20240112\r\n" +
+				"RDATE;VALUE=PERIOD:20240120T090000Z/PT8H\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "cancelled events and occurrences",
+			input: "BEGIN:VCALENDAR\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:leave\r\n" +
+				"DTSTART;VALUE=DATE:20240101\r\n" +
+				"RRULE:FREQ=WEEKLY;COUNT=3\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:leave\r\n" +
+				"RECURRENCE-ID;VALUE=DATE:20240108\r\n" +
+				"DTSTART;VALUE=DATE:20240109\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:leave\r\n" +
+				"RECURRENCE-ID;VALUE=DATE:20240115\r\n" +
+				"DTSTART;VALUE=DATE:20240115\r\n" +
+				"STATUS:CANCELLED\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"UID:trip\r\n" +
+				"DTSTART;VALUE=DATE:20240201\r\n" +
+				"DTEND;VALUE=DATE:20240205\r\n" +
+				"STATUS:CANCELLED\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "unsupported rrule part",
+			input: "BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:20240101\r\n" +
+				"RRULE:FREQ=YEARLY;BYMONTH=1,7;COUNT=4\r\n" +
+				"END:VEVENT\r\n",
+			wantErr: true,
+		},
+		{
+			name: "out of range rrule month day",
+			input: "BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:20240101\r\n" +
+				"RRULE:FREQ=DAILY;BYMONTHDAY=32;COUNT=1\r\n" +
+				"END:VEVENT\r\n",
+			wantErr: true,
+		},
+		{
+			name: "zero rrule count",
+			input: "BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:20240101\r\n" +
+				"RRULE:FREQ=DAILY;COUNT=0\r\n" +
+				"END:VEVENT\r\n",
+			wantErr: true,
+		},
+		{
+			name: "large rrule count without window",
+			input: "BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:20240101\r\n" +
+				"RRULE:FREQ=YEARLY;COUNT=1000000000\r\n" +
+				"END:VEVENT\r\n",
+			want: func() []dr.DateRange {
+				ranges := []dr.DateRange{}
+				for year := 2024; year < 2124; year++ {
+					ranges = append(ranges, dr.NewDateRange(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)))
+				}
+				return ranges
+			}(),
+		},
+		{
+			name: "endless rrule without window",
+			input: "BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:20240101\r\n" +
+				"RRULE:FREQ=DAILY\r\n" +
+				"END:VEVENT\r\n",
+			wantErr: true,
+		},
+		{
+			name: "missing dtstart",
+			input: "BEGIN:VCALENDAR\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"DTEND;VALUE=DATE:20240106\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+			wantErr: true,
+		},
+		{
+			name: "malformed date",
+			input: "BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:2024-01-01\r\n" +
+				"END:VEVENT\r\n",
+			wantErr: true,
+		},
+		{
+			name: "malformed duration",
+			input: "BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:20240101\r\n" +
+				"DURATION:P1H\r\n" +
+				"END:VEVENT\r\n",
+			wantErr: true,
+		},
+		{
+			name: "unterminated component",
+			input: "BEGIN:VCALENDAR\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:20240101\r\n",
+			wantErr: true,
+		},
+		{
+			name:    "mismatched end",
+			input:   "BEGIN:VCALENDAR\r\nEND:VEVENT\r\n",
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got, err := dr.NewICalDecoder(strings.NewReader(c.input)).Decode()
+			if (err != nil) != c.wantErr {
+				t.Fatalf("Decode() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if c.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("Decode() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.ICalDecoder.SetWindow with an endless recurring event
+func TestICalDecoderSetWindow(t *testing.T) {
+	input := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20240101\r\n" +
+		"RRULE:FREQ=MONTHLY;BYDAY=-1FR\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	dec := dr.NewICalDecoder(strings.NewReader(input))
+	dec.SetWindow(dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)))
+	got, err := dec.Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	want := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 1, 26, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 26, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 2, 23, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 23, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC)),
+	}
+	if !reflect.DeepEqual(got.ToSlice(), want) {
+		t.Errorf("Decode() = %v, want %v", got, want)
+	}
+}
+
+// test round trip of dr.ICalEncoder and dr.ICalDecoder
+func TestICalRoundTrip(t *testing.T) {
+	drs := dr.NewDateRanges(
+		dr.NewDateRange(time.Date(2023, 12, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)),
+	)
+	var buf bytes.Buffer
+	if err := dr.NewICalEncoder(&buf).Encode(drs); err != nil {
+		t.Fatalf("Encode(%v) error = %v", drs, err)
+	}
+	got, err := dr.NewICalDecoder(&buf).Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !got.Equal(drs) {
+		t.Errorf("Decode(Encode(%v)) = %v", drs, got)
+	}
+}
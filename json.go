@@ -0,0 +1,71 @@
+package daterange
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// dateRangeJSON is the JSON form of a DateRange.
+type dateRangeJSON struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// MarshalJSON implements json.Marshaler. A DateRange is encoded as an object with
+// its first and last dates, for example {"from":"2024-01-01","to":"2024-01-31"}.
+// Unbounded ends are encoded as "-inf" and "+inf", and an empty range as null.
+func (d DateRange) MarshalJSON() ([]byte, error) {
+	if d.IsEmpty() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(dateRangeJSON{
+		From: formatBound(d.from),
+		To:   formatBound(d.to),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the form written by
+// MarshalJSON. null decodes to an empty range.
+func (d *DateRange) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = DateRange{}
+		return nil
+	}
+	var v dateRangeJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	from, err := parseBound(v.From)
+	if err != nil {
+		return fmt.Errorf("daterange: malformed date %q", v.From)
+	}
+	to, err := parseBound(v.To)
+	if err != nil {
+		return fmt.Errorf("daterange: malformed date %q", v.To)
+	}
+	if isPosInf(from) || isNegInf(to) {
+		return fmt.Errorf("daterange: malformed range %s", data)
+	}
+	if to.Before(from) {
+		return fmt.Errorf("daterange: range %s ends before it starts", data)
+	}
+	*d = DateRange{from: from, to: to, nonEmpty: true}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. A DateRanges is encoded as an array
+// of its members, in ascending order.
+func (drs DateRanges) MarshalJSON() ([]byte, error) {
+	return json.Marshal(drs.ToSlice())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding an array of DateRange.
+// The members do not need to be sorted and overlapping members are merged.
+func (drs *DateRanges) UnmarshalJSON(data []byte) error {
+	var members []DateRange
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	*drs = NewDateRanges(members...)
+	return nil
+}
@@ -0,0 +1,162 @@
+package daterange_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// test dr.DateRange.MarshalJSON
+func TestDateRangeMarshalJSON(t *testing.T) {
+	cases := []struct {
+		name string
+		d    dr.DateRange
+		want string
+	}{
+		{
+			name: "zero",
+			d:    dr.DateRange{},
+			want: `null`,
+		},
+		{
+			name: "first date",
+			d:    dr.NewDateRange(time.Time{}, time.Time{}),
+			want: `{"from":"0001-01-01","to":"0001-01-01"}`,
+		},
+		{
+			name: "bounded",
+			d:    dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			want: `{"from":"2024-01-01","to":"2024-01-31"}`,
+		},
+		{
+			name: "no end",
+			d:    dr.NewDateRangeFrom(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			want: `{"from":"2024-01-01","to":"+inf"}`,
+		},
+		{
+			name: "unbounded",
+			d:    dr.NewUnboundedDateRange(),
+			want: `{"from":"-inf","to":"+inf"}`,
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got, err := json.Marshal(c.d)
+			if err != nil {
+				t.Fatalf("Marshal(%v) error = %v", c.d, err)
+			}
+			if string(got) != c.want {
+				t.Errorf("Marshal(%v) = %s, want %s", c.d, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRange.UnmarshalJSON
+func TestDateRangeUnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		name    string
+		data    string
+		want    dr.DateRange
+		wantErr bool
+	}{
+		{
+			name: "null",
+			data: `null`,
+			want: dr.DateRange{},
+		},
+		{
+			name: "first date",
+			data: `{"from":"0001-01-01","to":"0001-01-01"}`,
+			want: dr.NewDateRange(time.Time{}, time.Time{}),
+		},
+		{
+			name: "bounded",
+			data: `{"from":"2024-01-01","to":"2024-01-31"}`,
+			want: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "no start",
+			data: `{"from":"-inf","to":"2024-01-31"}`,
+			want: dr.NewDateRangeUntil(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:    "malformed date",
+			data:    `{"from":"2024-01-01","to":"2024-02-30"}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing date",
+			data:    `{"from":"2024-01-01"}`,
+			wantErr: true,
+		},
+		{
+			name:    "positive infinite start",
+			data:    `{"from":"+inf","to":"+inf"}`,
+			wantErr: true,
+		},
+		{
+			name:    "ends before it starts",
+			data:    `{"from":"2024-01-31","to":"2024-01-01"}`,
+			wantErr: true,
+		},
+		{
+			name:    "not an object",
+			data:    `"2024-01-01"`,
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			var got dr.DateRange
+			err := json.Unmarshal([]byte(c.data), &got)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, wantErr %v", c.data, err, c.wantErr)
+			}
+			if !c.wantErr && got != c.want {
+				t.Errorf("Unmarshal(%s) = %v, want %v", c.data, got, c.want)
+			}
+		})
+	}
+}
+
+// test JSON round trip of dr.DateRanges
+func TestDateRangesJSON(t *testing.T) {
+	drs := dr.NewDateRanges(
+		dr.NewDateRangeUntil(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)),
+	)
+	data, err := json.Marshal(drs)
+	if err != nil {
+		t.Fatalf("Marshal(%v) error = %v", drs, err)
+	}
+	if want := `[{"from":"-inf","to":"2023-12-31"},{"from":"2024-03-01","to":"2024-03-10"}]`; string(data) != want {
+		t.Errorf("Marshal(%v) = %s, want %s", drs, data, want)
+	}
+	var got dr.DateRanges
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal(%s) error = %v", data, err)
+	}
+	if !got.Equal(drs) {
+		t.Errorf("Unmarshal(%s) = %v, want %v", data, got, drs)
+	}
+
+	// members are normalized
+	if err := json.Unmarshal([]byte(`[{"from":"2024-01-05","to":"2024-01-10"},null,{"from":"2024-01-01","to":"2024-01-04"}]`), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))}
+	if !reflect.DeepEqual(got.ToSlice(), want) {
+		t.Errorf("Unmarshal() = %v, want %v", got, want)
+	}
+
+	empty, err := json.Marshal(dr.NewDateRanges())
+	if err != nil || string(empty) != `[]` {
+		t.Errorf("Marshal(empty) = %s, %v, want []", empty, err)
+	}
+}
@@ -0,0 +1,70 @@
+package daterange
+
+// MergeWithin returns a new collection where members separated by at most n
+// missing days are merged, together with the days between them.
+// A n less than 1 returns a copy of the collection, as adjacent members are always merged.
+func (drs *DateRanges) MergeWithin(n int) DateRanges {
+	merged := []DateRange{}
+	for _, dr := range drs.dr {
+		if last := len(merged) - 1; last >= 0 && daysBetween(merged[last].to, dr.from)-1 <= n {
+			merged[last].to = dr.to
+			continue
+		}
+		merged = append(merged, dr)
+	}
+	return NewDateRanges(merged...)
+}
+
+// DropShorterThan returns a new collection without the members shorter than m days.
+func (drs *DateRanges) DropShorterThan(m int) DateRanges {
+	kept := []DateRange{}
+	for _, dr := range drs.dr {
+		if dr.Days() >= m {
+			kept = append(kept, dr)
+		}
+	}
+	return NewDateRanges(kept...)
+}
+
+// Dilate returns a new collection where every member is extended by n days on both sides.
+// Unbounded ends stay unbounded.
+// Members that end up overlapping or adjacent are merged.
+// A n less than 1 returns a copy of the collection.
+func (drs *DateRanges) Dilate(n int) DateRanges {
+	if n < 0 {
+		n = 0
+	}
+	dilated := make([]DateRange, 0, len(drs.dr))
+	for _, dr := range drs.dr {
+		dilated = append(dilated, DateRange{
+			from:     addDays(dr.from, -n),
+			to:       addDays(dr.to, n),
+			nonEmpty: true,
+		})
+	}
+	return NewDateRanges(dilated...)
+}
+
+// Erode returns a new collection where every member is shrunk by n days on both sides.
+// Unbounded ends stay unbounded.
+// Members shorter than 2*n+1 days disappear.
+// A n less than 1 returns a copy of the collection.
+func (drs *DateRanges) Erode(n int) DateRanges {
+	if n < 0 {
+		n = 0
+	}
+	eroded := make([]DateRange, 0, len(drs.dr))
+	for _, dr := range drs.dr {
+		from := addDays(dr.from, n)
+		to := addDays(dr.to, -n)
+		if from.After(to) {
+			continue
+		}
+		eroded = append(eroded, DateRange{
+			from:     from,
+			to:       to,
+			nonEmpty: true,
+		})
+	}
+	return NewDateRanges(eroded...)
+}
@@ -0,0 +1,212 @@
+package daterange_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// test dr.DateRanges.MergeWithin
+func TestDateRangesMergeWithin(t *testing.T) {
+	drs := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+	}
+	cases := []struct {
+		name string
+		drs  []dr.DateRange
+		n    int
+		want []dr.DateRange
+	}{
+		{
+			name: "empty",
+			drs:  []dr.DateRange{},
+			n:    3,
+			want: []dr.DateRange{},
+		},
+		{
+			name: "zero",
+			drs:  drs,
+			n:    0,
+			want: drs,
+		},
+		{
+			name: "gap of one day",
+			drs:  drs,
+			n:    1,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "gap of three days chained",
+			drs:  drs,
+			n:    3,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(c.drs...)
+			got := drs.MergeWithin(c.n)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("NewDateRanges(%v).MergeWithin(%v) = %v, want %v", c.drs, c.n, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.DropShorterThan
+func TestDateRangesDropShorterThan(t *testing.T) {
+	drs := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)),
+	}
+	cases := []struct {
+		name string
+		m    int
+		want []dr.DateRange
+	}{
+		{
+			name: "keep all",
+			m:    1,
+			want: drs,
+		},
+		{
+			name: "at least two days",
+			m:    2,
+			want: []dr.DateRange{drs[0], drs[2]},
+		},
+		{
+			name: "drop all",
+			m:    4,
+			want: []dr.DateRange{},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(drs...)
+			got := drs.DropShorterThan(c.m)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("NewDateRanges(%v).DropShorterThan(%v) = %v, want %v", drs, c.m, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.Dilate
+func TestDateRangesDilate(t *testing.T) {
+	drs := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+	}
+	cases := []struct {
+		name string
+		n    int
+		want []dr.DateRange
+	}{
+		{
+			name: "zero",
+			n:    0,
+			want: drs,
+		},
+		{
+			name: "one day",
+			n:    1,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "huge",
+			n:    math.MaxInt,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(-1000000, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(1000000, 12, 30, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "merging",
+			n:    2,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 18, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 22, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(drs...)
+			got := drs.Dilate(c.n)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("NewDateRanges(%v).Dilate(%v) = %v, want %v", drs, c.n, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.Erode
+func TestDateRangesErode(t *testing.T) {
+	drs := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+	}
+	cases := []struct {
+		name string
+		n    int
+		want []dr.DateRange
+	}{
+		{
+			name: "zero",
+			n:    0,
+			want: drs,
+		},
+		{
+			name: "one day",
+			n:    1,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "huge",
+			n:    math.MaxInt,
+			want: []dr.DateRange{},
+		},
+		{
+			name: "three days",
+			n:    3,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(drs...)
+			got := drs.Erode(c.n)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("NewDateRanges(%v).Erode(%v) = %v, want %v", drs, c.n, got, c.want)
+			}
+		})
+	}
+}
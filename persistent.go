@@ -0,0 +1,614 @@
+package daterange
+
+import "time"
+
+// PersistentDateRanges is an immutable collection of DateRange elements.
+// Add and Remove return a new version and leave the receiver unchanged. The
+// versions share all unchanged structure, so every change costs O(log n) time
+// and memory instead of a copy of the whole collection.
+//
+// As for DateRanges, members are sorted, non-overlapping and non-empty, and
+// adjacent periods are merged. The zero value is an empty collection ready to use.
+//
+// The queries of DateRanges are available with the same names. Those returning
+// a collection return a PersistentDateRanges. Clip, SplitInclusive, Difference,
+// Complement and StreakEndingAt work on the tree, the others copy the members.
+// The rest of the DateRanges API, such as Prorate, FreeSlots or Reduce, is
+// available through ToDateRanges.
+type PersistentDateRanges struct {
+	root *pnode
+}
+
+// pnode is a node of an AVL tree ordered by date. Nodes are never modified
+// after creation, so they can be shared between versions.
+type pnode struct {
+	dr          DateRange
+	left, right *pnode
+	height      int
+	size        int // number of members in the subtree
+	days        int // number of dates in the subtree
+}
+
+// NewPersistentDateRanges returns a new immutable collection with given elements
+func NewPersistentDateRanges(dataRanges ...DateRange) PersistentDateRanges {
+	drs := NewDateRanges(dataRanges...)
+	return drs.ToPersistent()
+}
+
+// ToPersistent returns an immutable collection with the members of the collection.
+// It runs in O(n) of the number of members.
+func (drs *DateRanges) ToPersistent() PersistentDateRanges {
+	return PersistentDateRanges{root: buildPNodes(drs.dr)}
+}
+
+// ToDateRanges returns a mutable collection with the members of the collection.
+// It runs in O(n) of the number of members.
+func (p PersistentDateRanges) ToDateRanges() DateRanges {
+	drs := DateRanges{dr: p.ToSlice()}
+	drs.index()
+	return drs
+}
+
+// ToSlice returns the members of the collection as a slice.
+func (p PersistentDateRanges) ToSlice() []DateRange {
+	slice := make([]DateRange, 0, p.Len())
+	p.Each(func(dr DateRange) bool {
+		slice = append(slice, dr)
+		return true
+	})
+	return slice
+}
+
+// String returns a string representation of the collection.
+func (p PersistentDateRanges) String() string {
+	return p.ToDateRanges().String()
+}
+
+// IsZero returns true if the collection is empty
+func (p PersistentDateRanges) IsZero() bool {
+	return p.root == nil
+}
+
+// Len returns the number of elements in the collection
+func (p PersistentDateRanges) Len() int {
+	return p.root.sizeOf()
+}
+
+// TotalDays returns the number of dates in the collection.
+// It returns math.MaxInt if the collection is unbounded.
+func (p PersistentDateRanges) TotalDays() int {
+	return p.root.daysOf()
+}
+
+// FirstDate returns the first date of the collection
+func (p PersistentDateRanges) FirstDate() time.Time {
+	if p.root == nil {
+		return time.Time{}
+	}
+	n := p.root
+	for n.left != nil {
+		n = n.left
+	}
+	return n.dr.from
+}
+
+// FirstDateOK returns the first date of the collection, or false if it is empty
+func (p PersistentDateRanges) FirstDateOK() (time.Time, bool) {
+	return p.FirstDate(), p.root != nil
+}
+
+// LastDate returns the last date of the collection
+func (p PersistentDateRanges) LastDate() time.Time {
+	if p.root == nil {
+		return time.Time{}
+	}
+	n := p.root
+	for n.right != nil {
+		n = n.right
+	}
+	return n.dr.to
+}
+
+// LastDateOK returns the last date of the collection, or false if it is empty
+func (p PersistentDateRanges) LastDateOK() (time.Time, bool) {
+	return p.LastDate(), p.root != nil
+}
+
+// Equal returns true if the collection is equal to the given collection
+func (p PersistentDateRanges) Equal(other PersistentDateRanges) bool {
+	if p.root == other.root {
+		return true
+	}
+	if p.Len() != other.Len() || p.TotalDays() != other.TotalDays() {
+		return false
+	}
+	mine, theirs := p.ToSlice(), other.ToSlice()
+	for i, dr := range mine {
+		if dr != theirs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Each calls f for every member of the collection in ascending order until f
+// returns false. Each has the signature of iter.Seq[DateRange].
+func (p PersistentDateRanges) Each(f func(DateRange) bool) {
+	p.root.each(f)
+}
+
+// Contains returns true if the given date is in the collection
+func (p PersistentDateRanges) Contains(date time.Time) bool {
+	date = toDateUTC(date)
+	for n := p.root; n != nil; {
+		switch {
+		case date.Before(n.dr.from):
+			n = n.left
+		case date.After(n.dr.to):
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// IsAnyDateIn returns true if any date in the given DateRange is in the collection
+// The empty DateRange is always considered to be in the collection
+func (p PersistentDateRanges) IsAnyDateIn(other DateRange) bool {
+	if other.IsEmpty() {
+		return true
+	}
+	// first member ending on or after the start of other
+	var found *pnode
+	for n := p.root; n != nil; {
+		if n.dr.to.Before(other.from) {
+			n = n.right
+		} else {
+			found = n
+			n = n.left
+		}
+	}
+	return found != nil && !found.dr.from.After(other.to)
+}
+
+// IsAllDatesIn returns true if all dates in the given DateRange are in the collection
+// The empty DateRange is always considered to be in the collection
+func (p PersistentDateRanges) IsAllDatesIn(other DateRange) bool {
+	if other.IsEmpty() {
+		return true
+	}
+	for n := p.root; n != nil; {
+		switch {
+		case other.from.Before(n.dr.from):
+			n = n.left
+		case other.from.After(n.dr.to):
+			n = n.right
+		default:
+			return n.dr.Includes(other)
+		}
+	}
+	return false
+}
+
+// NthDate returns the n-th date of the collection, counting from 1. Negative
+// values of n count from the end, -1 being the last date. It returns false if
+// n is 0 or out of range, or if the collection has no start, or no end for
+// negative values of n. It runs in O(log n) of the number of members.
+func (p PersistentDateRanges) NthDate(n int) (time.Time, bool) {
+	if p.IsZero() || isNegInf(p.FirstDate()) || (n < 0 && isPosInf(p.LastDate())) {
+		return time.Time{}, false
+	}
+	total := p.TotalDays()
+	idx := n - 1
+	if n < 0 {
+		idx = total + n
+	}
+	if n == 0 || idx < 0 || idx >= total {
+		return time.Time{}, false
+	}
+	node := p.root
+	for {
+		left := node.left.daysOf()
+		own := node.dr.Days()
+		switch {
+		case idx < left:
+			node = node.left
+		case idx < addSaturated(left, own):
+			if node.dr.IsToInf() && idx-left >= daysBetween(node.dr.from, posInf) {
+				return time.Time{}, false
+			}
+			return node.dr.from.AddDate(0, 0, idx-left), true
+		default:
+			idx -= left + own
+			node = node.right
+		}
+	}
+}
+
+// Rank returns the position of the given date among the dates of the collection,
+// counting from 1. It returns false if the date is not in the collection or
+// if the collection has no start. It runs in O(log n) of the number of members.
+func (p PersistentDateRanges) Rank(date time.Time) (int, bool) {
+	date = toDateUTC(date)
+	if p.IsZero() || isNegInf(p.FirstDate()) || isPosInf(date) {
+		return 0, false
+	}
+	before := 0
+	for n := p.root; n != nil; {
+		switch {
+		case date.Before(n.dr.from):
+			n = n.left
+		case date.After(n.dr.to):
+			before += n.left.daysOf() + n.dr.Days()
+			n = n.right
+		default:
+			return before + n.left.daysOf() + daysBetween(n.dr.from, date) + 1, true
+		}
+	}
+	return 0, false
+}
+
+// Add returns a new version of the collection with the given elements added.
+func (p PersistentDateRanges) Add(dataRange ...DateRange) PersistentDateRanges {
+	root := p.root
+	for _, dr := range dataRange {
+		if dr.IsEmpty() {
+			continue
+		}
+		// members ending before the day preceding dr are kept on the left,
+		// members starting after the day following dr are kept on the right,
+		// all others overlap or are adjacent and are merged with dr
+		left, rest := splitPNodes(root, func(m DateRange) bool {
+			return m.to.Before(dr.from.AddDate(0, 0, -1))
+		})
+		middle, right := splitPNodes(rest, func(m DateRange) bool {
+			return !m.from.After(dr.to.AddDate(0, 0, 1))
+		})
+		if middle != nil {
+			dr.from = minTime(dr.from, middle.first().from)
+			dr.to = maxTime(dr.to, middle.last().to)
+		}
+		root = joinPNodes(left, dr, right)
+	}
+	return PersistentDateRanges{root: root}
+}
+
+// Remove returns a new version of the collection with the dates of the given
+// elements removed. Members are split when a hole is removed from their middle.
+func (p PersistentDateRanges) Remove(dataRange ...DateRange) PersistentDateRanges {
+	root := p.root
+	for _, dr := range dataRange {
+		if dr.IsEmpty() {
+			continue
+		}
+		left, rest := splitPNodes(root, func(m DateRange) bool {
+			return m.to.Before(dr.from)
+		})
+		middle, right := splitPNodes(rest, func(m DateRange) bool {
+			return !m.from.After(dr.to)
+		})
+		if middle != nil {
+			if first := middle.first(); first.from.Before(dr.from) {
+				left = joinPNodes(left, DateRange{from: first.from, to: dr.from.AddDate(0, 0, -1), nonEmpty: true}, nil)
+			}
+			if last := middle.last(); last.to.After(dr.to) {
+				right = joinPNodes(nil, DateRange{from: dr.to.AddDate(0, 0, 1), to: last.to, nonEmpty: true}, right)
+			}
+		}
+		root = concatPNodes(left, right)
+	}
+	return PersistentDateRanges{root: root}
+}
+
+// SplitInclusive splits the collection into two collections based on the given date
+// The given date is included in both collections. It runs in O(log n) of the number of members.
+func (p PersistentDateRanges) SplitInclusive(date time.Time) (PersistentDateRanges, PersistentDateRanges) {
+	date = toDateUTC(date)
+	before := p.Clip(DateRange{from: negInf, to: date, nonEmpty: true})
+	after := p.Clip(DateRange{from: date, to: posInf, nonEmpty: true})
+	return before, after
+}
+
+// Clip returns a new collection with the dates of the collection that are within the given bounds.
+// It runs in O(log n) of the number of members.
+func (p PersistentDateRanges) Clip(bounds DateRange) PersistentDateRanges {
+	if bounds.IsEmpty() {
+		return PersistentDateRanges{}
+	}
+	clipped := p
+	if !bounds.IsFromInf() {
+		clipped = clipped.Remove(DateRange{from: negInf, to: bounds.from.AddDate(0, 0, -1), nonEmpty: true})
+	}
+	if !bounds.IsToInf() {
+		clipped = clipped.Remove(DateRange{from: bounds.to.AddDate(0, 0, 1), to: posInf, nonEmpty: true})
+	}
+	return clipped
+}
+
+// Difference returns a new collection with the dates of the collection that
+// are not in the other collection. It runs in O(m log n) for m members in the
+// other collection.
+func (p PersistentDateRanges) Difference(other PersistentDateRanges) PersistentDateRanges {
+	return p.Remove(other.ToSlice()...)
+}
+
+// Complement returns a new collection with the dates of the given range that
+// are not in the collection, i.e. the gaps of the collection within the range.
+func (p PersistentDateRanges) Complement(within DateRange) PersistentDateRanges {
+	full := NewPersistentDateRanges(within)
+	return full.Difference(p.Clip(within))
+}
+
+// Filter returns a new collection with the members for which keep returns true.
+func (p PersistentDateRanges) Filter(keep func(DateRange) bool) PersistentDateRanges {
+	drs := p.ToDateRanges()
+	filtered := drs.Filter(keep)
+	return filtered.ToPersistent()
+}
+
+// Map returns a new collection with the result of f for every member.
+// The result is normalized as for DateRanges.Map.
+func (p PersistentDateRanges) Map(f func(DateRange) DateRange) PersistentDateRanges {
+	drs := p.ToDateRanges()
+	mapped := drs.Map(f)
+	return mapped.ToPersistent()
+}
+
+// AlignOutward returns a new collection with every member extended to whole
+// calendar periods of the given Granularity, see DateRanges.AlignOutward.
+func (p PersistentDateRanges) AlignOutward(g Granularity) PersistentDateRanges {
+	drs := p.ToDateRanges()
+	aligned := drs.AlignOutward(g)
+	return aligned.ToPersistent()
+}
+
+// AlignInward returns a new collection with every member shrunk to the whole
+// calendar periods of the given Granularity it contains, see DateRanges.AlignInward.
+func (p PersistentDateRanges) AlignInward(g Granularity) PersistentDateRanges {
+	drs := p.ToDateRanges()
+	aligned := drs.AlignInward(g)
+	return aligned.ToPersistent()
+}
+
+// LongestStreak returns the longest run of consecutive dates in the collection.
+// If several runs have the same length, the earliest one is returned.
+// It returns an empty DateRange if the collection is empty.
+func (p PersistentDateRanges) LongestStreak() DateRange {
+	longest := DateRange{}
+	p.Each(func(dr DateRange) bool {
+		if dr.Days() > longest.Days() {
+			longest = dr
+		}
+		return true
+	})
+	return longest
+}
+
+// StreakEndingAt returns the run of consecutive dates of the collection that ends
+// on the given date. It returns an empty DateRange if the date is not in the collection.
+// It runs in O(log n) of the number of members.
+func (p PersistentDateRanges) StreakEndingAt(date time.Time) DateRange {
+	date = toDateUTC(date)
+	for n := p.root; n != nil; {
+		switch {
+		case date.Before(n.dr.from):
+			n = n.left
+		case date.After(n.dr.to):
+			n = n.right
+		default:
+			return DateRange{from: n.dr.from, to: date, nonEmpty: true}
+		}
+	}
+	return DateRange{}
+}
+
+// StreaksOfAtLeast returns the runs of consecutive dates that are at least n days long.
+func (p PersistentDateRanges) StreaksOfAtLeast(n int) PersistentDateRanges {
+	return p.DropShorterThan(n)
+}
+
+// MergeWithin returns a new collection where members separated by at most n
+// missing days are merged, see DateRanges.MergeWithin.
+func (p PersistentDateRanges) MergeWithin(n int) PersistentDateRanges {
+	drs := p.ToDateRanges()
+	merged := drs.MergeWithin(n)
+	return merged.ToPersistent()
+}
+
+// DropShorterThan returns a new collection without the members shorter than m days.
+func (p PersistentDateRanges) DropShorterThan(m int) PersistentDateRanges {
+	return p.Filter(func(dr DateRange) bool {
+		return dr.Days() >= m
+	})
+}
+
+// Dilate returns a new collection where every member is extended by n days on
+// both sides, see DateRanges.Dilate.
+func (p PersistentDateRanges) Dilate(n int) PersistentDateRanges {
+	drs := p.ToDateRanges()
+	dilated := drs.Dilate(n)
+	return dilated.ToPersistent()
+}
+
+// Erode returns a new collection where every member is shrunk by n days on
+// both sides, see DateRanges.Erode.
+func (p PersistentDateRanges) Erode(n int) PersistentDateRanges {
+	drs := p.ToDateRanges()
+	eroded := drs.Erode(n)
+	return eroded.ToPersistent()
+}
+
+// AddDate returns a new version of the collection with the given date added.
+func (p PersistentDateRanges) AddDate(date time.Time) PersistentDateRanges {
+	return p.Add(NewDateRange(date, date))
+}
+
+// RemoveDate returns a new version of the collection with the given date removed.
+func (p PersistentDateRanges) RemoveDate(date time.Time) PersistentDateRanges {
+	return p.Remove(NewDateRange(date, date))
+}
+
+// buildPNodes returns a balanced tree of the given sorted members.
+func buildPNodes(drs []DateRange) *pnode {
+	if len(drs) == 0 {
+		return nil
+	}
+	mid := len(drs) / 2
+	return newPNode(buildPNodes(drs[:mid]), drs[mid], buildPNodes(drs[mid+1:]))
+}
+
+// newPNode returns a new node with the given children, computing its aggregates.
+func newPNode(left *pnode, dr DateRange, right *pnode) *pnode {
+	height := left.heightOf()
+	if h := right.heightOf(); h > height {
+		height = h
+	}
+	return &pnode{
+		dr:     dr,
+		left:   left,
+		right:  right,
+		height: height + 1,
+		size:   left.sizeOf() + right.sizeOf() + 1,
+		days:   addSaturated(addSaturated(left.daysOf(), right.daysOf()), dr.Days()),
+	}
+}
+
+func (n *pnode) heightOf() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *pnode) sizeOf() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *pnode) daysOf() int {
+	if n == nil {
+		return 0
+	}
+	return n.days
+}
+
+// first returns the first member of a non-empty tree.
+func (n *pnode) first() DateRange {
+	for n.left != nil {
+		n = n.left
+	}
+	return n.dr
+}
+
+// last returns the last member of a non-empty tree.
+func (n *pnode) last() DateRange {
+	for n.right != nil {
+		n = n.right
+	}
+	return n.dr
+}
+
+// each calls f for every member of the tree in order. It returns false if f did.
+func (n *pnode) each(f func(DateRange) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.left.each(f) && f(n.dr) && n.right.each(f)
+}
+
+// rotateLeft returns a new tree with the right child of n as root.
+func rotateLeft(n *pnode) *pnode {
+	r := n.right
+	return newPNode(newPNode(n.left, n.dr, r.left), r.dr, r.right)
+}
+
+// rotateRight returns a new tree with the left child of n as root.
+func rotateRight(n *pnode) *pnode {
+	l := n.left
+	return newPNode(l.left, l.dr, newPNode(l.right, n.dr, n.right))
+}
+
+// joinPNodes returns a balanced tree of all members of left, then dr, then all
+// members of right. Every member of left must be before dr and every member of
+// right after it. It runs in O(|height(left) - height(right)|).
+func joinPNodes(left *pnode, dr DateRange, right *pnode) *pnode {
+	switch {
+	case left.heightOf() > right.heightOf()+1:
+		return joinPNodesRight(left, dr, right)
+	case right.heightOf() > left.heightOf()+1:
+		return joinPNodesLeft(left, dr, right)
+	}
+	return newPNode(left, dr, right)
+}
+
+// joinPNodesRight joins a tree taller than right by walking down the right spine of left.
+func joinPNodesRight(left *pnode, dr DateRange, right *pnode) *pnode {
+	if left.right.heightOf() <= right.heightOf()+1 {
+		joined := newPNode(left.right, dr, right)
+		if joined.height <= left.left.heightOf()+1 {
+			return newPNode(left.left, left.dr, joined)
+		}
+		return rotateLeft(newPNode(left.left, left.dr, rotateRight(joined)))
+	}
+	joined := joinPNodesRight(left.right, dr, right)
+	n := newPNode(left.left, left.dr, joined)
+	if joined.height <= left.left.heightOf()+1 {
+		return n
+	}
+	return rotateLeft(n)
+}
+
+// joinPNodesLeft joins a tree taller than left by walking down the left spine of right.
+func joinPNodesLeft(left *pnode, dr DateRange, right *pnode) *pnode {
+	if right.left.heightOf() <= left.heightOf()+1 {
+		joined := newPNode(left, dr, right.left)
+		if joined.height <= right.right.heightOf()+1 {
+			return newPNode(joined, right.dr, right.right)
+		}
+		return rotateRight(newPNode(rotateLeft(joined), right.dr, right.right))
+	}
+	joined := joinPNodesLeft(left, dr, right.left)
+	n := newPNode(joined, right.dr, right.right)
+	if joined.height <= right.right.heightOf()+1 {
+		return n
+	}
+	return rotateRight(n)
+}
+
+// concatPNodes returns a balanced tree of all members of left followed by all members of right.
+func concatPNodes(left, right *pnode) *pnode {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	rest, last := splitLastPNode(left)
+	return joinPNodes(rest, last, right)
+}
+
+// splitLastPNode returns the tree without its last member, and that member.
+func splitLastPNode(n *pnode) (*pnode, DateRange) {
+	if n.right == nil {
+		return n.left, n.dr
+	}
+	rest, last := splitLastPNode(n.right)
+	return joinPNodes(n.left, n.dr, rest), last
+}
+
+// splitPNodes splits the tree into the members for which before returns true and
+// the others. before must return true for a prefix of the members and false for the rest.
+func splitPNodes(n *pnode, before func(DateRange) bool) (*pnode, *pnode) {
+	if n == nil {
+		return nil, nil
+	}
+	if before(n.dr) {
+		left, right := splitPNodes(n.right, before)
+		return joinPNodes(n.left, n.dr, left), right
+	}
+	left, right := splitPNodes(n.left, before)
+	return left, joinPNodes(right, n.dr, n.right)
+}
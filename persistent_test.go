@@ -0,0 +1,284 @@
+package daterange_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// test dr.PersistentDateRanges.Add
+func TestPersistentDateRangesAdd(t *testing.T) {
+	cases := []struct {
+		name  string
+		drs   []dr.DateRange
+		added []dr.DateRange
+		want  []dr.DateRange
+	}{
+		{
+			name:  "empty",
+			drs:   []dr.DateRange{},
+			added: []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+			want:  []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:  "zero range",
+			drs:   []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+			added: []dr.DateRange{{}},
+			want:  []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name: "merges overlapping and adjacent",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			added: []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC))},
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "disjoint",
+			drs:  []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC))},
+			added: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			p := dr.NewPersistentDateRanges(c.drs...)
+			got := p.Add(c.added...)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("NewPersistentDateRanges(%v).Add(%v) = %v, want %v", c.drs, c.added, got, c.want)
+			}
+			// the original version is unchanged
+			if !reflect.DeepEqual(p.ToSlice(), dr.NewPersistentDateRanges(c.drs...).ToSlice()) {
+				t.Errorf("NewPersistentDateRanges(%v).Add(%v) changed the receiver to %v", c.drs, c.added, p)
+			}
+		})
+	}
+}
+
+// test dr.PersistentDateRanges.Remove
+func TestPersistentDateRangesRemove(t *testing.T) {
+	cases := []struct {
+		name    string
+		drs     []dr.DateRange
+		removed []dr.DateRange
+		want    []dr.DateRange
+	}{
+		{
+			name:    "empty",
+			drs:     []dr.DateRange{},
+			removed: []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+			want:    []dr.DateRange{},
+		},
+		{
+			name:    "hole in the middle",
+			drs:     []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))},
+			removed: []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC))},
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "across members",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+			},
+			removed: []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC))},
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:    "everything",
+			drs:     []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+			removed: []dr.DateRange{dr.NewDateRange(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC))},
+			want:    []dr.DateRange{},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			p := dr.NewPersistentDateRanges(c.drs...)
+			got := p.Remove(c.removed...)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("NewPersistentDateRanges(%v).Remove(%v) = %v, want %v", c.drs, c.removed, got, c.want)
+			}
+			if !reflect.DeepEqual(p.ToSlice(), dr.NewPersistentDateRanges(c.drs...).ToSlice()) {
+				t.Errorf("NewPersistentDateRanges(%v).Remove(%v) changed the receiver to %v", c.drs, c.removed, p)
+			}
+		})
+	}
+}
+
+// test that dr.PersistentDateRanges answers queries like dr.DateRanges after random changes
+func TestPersistentDateRangesMatchesDateRanges(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	randomRange := func() dr.DateRange {
+		from := start.AddDate(0, 0, rnd.Intn(365))
+		return dr.NewDateRange(from, from.AddDate(0, 0, rnd.Intn(10)))
+	}
+
+	drs := dr.NewDateRanges()
+	p := dr.PersistentDateRanges{}
+	versions := []dr.PersistentDateRanges{}
+	snapshots := [][]dr.DateRange{}
+	for i := 0; i < 500; i++ {
+		r := randomRange()
+		if rnd.Intn(3) == 0 {
+			drs.Remove(r)
+			p = p.Remove(r)
+		} else {
+			drs.Append(r)
+			p = p.Add(r)
+		}
+		versions = append(versions, p)
+		snapshots = append(snapshots, drs.ToSlice())
+
+		if !reflect.DeepEqual(p.ToSlice(), drs.ToSlice()) {
+			t.Fatalf("step %d: got %v, want %v", i, p, drs)
+		}
+		if p.Len() != drs.Len() || p.TotalDays() != drs.TotalDays() {
+			t.Fatalf("step %d: Len() = %v, TotalDays() = %v, want %v, %v", i, p.Len(), p.TotalDays(), drs.Len(), drs.TotalDays())
+		}
+		if !p.FirstDate().Equal(drs.FirstDate()) || !p.LastDate().Equal(drs.LastDate()) {
+			t.Fatalf("step %d: FirstDate() = %v, LastDate() = %v, want %v, %v", i, p.FirstDate(), p.LastDate(), drs.FirstDate(), drs.LastDate())
+		}
+		if _, ok := p.FirstDateOK(); ok != (drs.Len() > 0) {
+			t.Fatalf("step %d: FirstDateOK() = %v, want %v", i, ok, drs.Len() > 0)
+		}
+		if _, ok := p.LastDateOK(); ok != (drs.Len() > 0) {
+			t.Fatalf("step %d: LastDateOK() = %v, want %v", i, ok, drs.Len() > 0)
+		}
+		q := randomRange()
+		if p.IsAnyDateIn(q) != drs.IsAnyDateIn(q) || p.IsAllDatesIn(q) != drs.IsAllDatesIn(q) {
+			t.Fatalf("step %d: IsAnyDateIn(%v) = %v, IsAllDatesIn(%v) = %v", i, q, p.IsAnyDateIn(q), q, p.IsAllDatesIn(q))
+		}
+		date := q.From()
+		gotRank, gotOk := p.Rank(date)
+		wantRank, wantOk := drs.Rank(date)
+		if p.Contains(date) != drs.Contains(date) || gotRank != wantRank || gotOk != wantOk {
+			t.Fatalf("step %d: Contains(%v) = %v, Rank(%v) = %v, %v", i, date, p.Contains(date), date, gotRank, gotOk)
+		}
+		n := rnd.Intn(2*drs.TotalDays()+3) - drs.TotalDays() - 1
+		gotDate, gotOk := p.NthDate(n)
+		wantDate, wantOk := drs.NthDate(n)
+		if !gotDate.Equal(wantDate) || gotOk != wantOk {
+			t.Fatalf("step %d: NthDate(%v) = %v, %v, want %v, %v", i, n, gotDate, gotOk, wantDate, wantOk)
+		}
+	}
+
+	// older versions are unchanged by later changes
+	for i, v := range versions {
+		if !reflect.DeepEqual(v.ToSlice(), snapshots[i]) {
+			t.Fatalf("version %d = %v, want %v", i, v, snapshots[i])
+		}
+	}
+}
+
+// test the collection queries of dr.PersistentDateRanges against dr.DateRanges on random collections
+func TestPersistentDateRangesQueries(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	randomRange := func() dr.DateRange {
+		from := start.AddDate(0, 0, rnd.Intn(120))
+		return dr.NewDateRange(from, from.AddDate(0, 0, rnd.Intn(20)))
+	}
+	same := func(name string, got dr.PersistentDateRanges, want dr.DateRanges) {
+		t.Helper()
+		if !reflect.DeepEqual(got.ToSlice(), want.ToSlice()) {
+			t.Fatalf("%s = %v, want %v", name, got, want)
+		}
+	}
+	for round := 0; round < 200; round++ {
+		drs, other := dr.NewDateRanges(), dr.NewDateRanges()
+		for i := rnd.Intn(8); i > 0; i-- {
+			drs.Append(randomRange())
+			other.Append(randomRange())
+		}
+		if rnd.Intn(4) == 0 {
+			drs.Append(dr.NewDateRangeUntil(start.AddDate(0, 0, -rnd.Intn(5))))
+		}
+		if rnd.Intn(4) == 0 {
+			drs.Append(dr.NewDateRangeFrom(start.AddDate(0, 0, 150+rnd.Intn(5))))
+		}
+		p := drs.ToPersistent()
+		q := randomRange()
+		date := q.From()
+		n := rnd.Intn(5)
+
+		before, after := p.SplitInclusive(date)
+		wantBefore, wantAfter := drs.SplitInclusive(date)
+		same("SplitInclusive before", before, wantBefore)
+		same("SplitInclusive after", after, wantAfter)
+		same("Clip", p.Clip(q), drs.Clip(q))
+		same("Clip unbounded", p.Clip(dr.NewDateRangeFrom(date)), drs.Clip(dr.NewDateRangeFrom(date)))
+		same("Difference", p.Difference(other.ToPersistent()), drs.Difference(other))
+		same("Complement", p.Complement(q), drs.Complement(q))
+		keep := func(d dr.DateRange) bool { return d.Days()%2 == 0 }
+		same("Filter", p.Filter(keep), drs.Filter(keep))
+		shift := func(d dr.DateRange) dr.DateRange {
+			return dr.NewDateRange(d.From().AddDate(0, 0, 3), d.To())
+		}
+		same("Map", p.Map(shift), drs.Map(shift))
+		same("AlignOutward", p.AlignOutward(dr.ISOWeek), drs.AlignOutward(dr.ISOWeek))
+		same("AlignInward", p.AlignInward(dr.ISOWeek), drs.AlignInward(dr.ISOWeek))
+		same("StreaksOfAtLeast", p.StreaksOfAtLeast(n), drs.StreaksOfAtLeast(n))
+		same("MergeWithin", p.MergeWithin(n), drs.MergeWithin(n))
+		same("DropShorterThan", p.DropShorterThan(n), drs.DropShorterThan(n))
+		same("Dilate", p.Dilate(n), drs.Dilate(n))
+		same("Erode", p.Erode(n), drs.Erode(n))
+		if got, want := p.LongestStreak(), drs.LongestStreak(); got != want {
+			t.Fatalf("LongestStreak() = %v, want %v", got, want)
+		}
+		if got, want := p.StreakEndingAt(date), drs.StreakEndingAt(date); got != want {
+			t.Fatalf("StreakEndingAt(%v) = %v, want %v", date, got, want)
+		}
+	}
+}
+
+// test conversion between dr.DateRanges and dr.PersistentDateRanges
+func TestPersistentDateRangesConversion(t *testing.T) {
+	drs := dr.NewDateRanges(
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+	)
+	p := drs.ToPersistent()
+	back := p.ToDateRanges()
+	if !back.Equal(drs) {
+		t.Errorf("ToDateRanges() = %v, want %v", back, drs)
+	}
+	if back.TotalDays() != 8 {
+		t.Errorf("ToDateRanges().TotalDays() = %v, want %v", back.TotalDays(), 8)
+	}
+	if got, want := p.String(), drs.String(); got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+	if !p.Equal(dr.NewPersistentDateRanges(drs.ToSlice()...)) {
+		t.Errorf("Equal() = false, want true")
+	}
+	if p.Equal(p.RemoveDate(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))) {
+		t.Errorf("Equal() = true, want false")
+	}
+}
@@ -0,0 +1,129 @@
+package daterange
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// Prorate distributes an amount in integer minor units, such as cents, over the
+// given parts in proportion to their number of days. The result has one share
+// per part and the shares always add up to amount.
+//
+// Every part first gets the integer part of its exact share. The units left
+// over are then given one by one to the parts with the largest remainders,
+// ties going to the earlier part, so the result is deterministic. Negative
+// amounts are distributed as their absolute value and then negated.
+//
+// If no part has any days, every share is 0. If any part is unbounded, the
+// amount is split evenly over the unbounded parts and the others get nothing.
+func Prorate(amount int64, parts ...DateRange) []int64 {
+	if weights, ok := unboundedWeights(parts); ok {
+		return largestRemainder(amount, weights)
+	}
+	weights := make([]uint64, len(parts))
+	for i, part := range parts {
+		weights[i] = uint64(part.Days())
+	}
+	return largestRemainder(amount, weights)
+}
+
+// ProrateWith is like Prorate, but weights every part by its day count in the
+// given convention, for example Thirty360E{} to count every month as 30 days.
+// Parts with a negative day count get no share. Unbounded parts are handled as in Prorate.
+func ProrateWith(amount int64, conv DayCountConvention, parts ...DateRange) []int64 {
+	if weights, ok := unboundedWeights(parts); ok {
+		return largestRemainder(amount, weights)
+	}
+	weights := make([]uint64, len(parts))
+	for i, part := range parts {
+		if days := conv.DayCount(part); days > 0 {
+			weights[i] = uint64(days)
+		}
+	}
+	return largestRemainder(amount, weights)
+}
+
+// ProrateDaily distributes an amount in integer minor units over the dates of
+// the range, with the rounding of Prorate. The result has one share per date.
+// An unbounded range returns no shares.
+func (d DateRange) ProrateDaily(amount int64) []int64 {
+	if !d.IsBounded() {
+		return []int64{}
+	}
+	weights := make([]uint64, d.Days())
+	for i := range weights {
+		weights[i] = 1
+	}
+	return largestRemainder(amount, weights)
+}
+
+// Prorate distributes an amount in integer minor units over the members of the
+// collection in proportion to their number of days, with the rounding of the
+// Prorate function. The result has one share per member.
+func (drs *DateRanges) Prorate(amount int64) []int64 {
+	return Prorate(amount, drs.dr...)
+}
+
+// unboundedWeights returns a weight of 1 for the unbounded parts and 0 for the
+// others. It returns false if no part is unbounded.
+func unboundedWeights(parts []DateRange) ([]uint64, bool) {
+	weights := make([]uint64, len(parts))
+	found := false
+	for i, part := range parts {
+		if !part.IsEmpty() && !part.IsBounded() {
+			weights[i] = 1
+			found = true
+		}
+	}
+	return weights, found
+}
+
+// largestRemainder distributes amount in proportion to the given weights using
+// the largest remainder method. The products are computed on 128 bits, so
+// any amount and weights are supported without overflow.
+func largestRemainder(amount int64, weights []uint64) []int64 {
+	shares := make([]int64, len(weights))
+	var total uint64
+	for _, w := range weights {
+		total += w
+	}
+	if total == 0 {
+		return shares
+	}
+
+	negative := amount < 0
+	abs := uint64(amount)
+	if negative {
+		abs = -abs
+	}
+
+	remainders := make([]uint64, len(weights))
+	var distributed uint64
+	for i, w := range weights {
+		// w <= total, so the quotient fits in 64 bits
+		hi, lo := bits.Mul64(abs, w)
+		quo, rem := bits.Div64(hi, lo, total)
+		shares[i] = int64(quo)
+		remainders[i] = rem
+		distributed += quo
+	}
+
+	// give the units left over to the largest remainders, fewer than len(weights) are left
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]] > remainders[order[j]]
+	})
+	for _, i := range order[:abs-distributed] {
+		shares[i]++
+	}
+
+	if negative {
+		for i := range shares {
+			shares[i] = -shares[i]
+		}
+	}
+	return shares
+}
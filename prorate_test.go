@@ -0,0 +1,210 @@
+package daterange_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// test dr.Prorate
+func TestProrate(t *testing.T) {
+	cases := []struct {
+		name   string
+		amount int64
+		parts  []dr.DateRange
+		want   []int64
+	}{
+		{
+			name:   "no parts",
+			amount: 1000,
+			parts:  []dr.DateRange{},
+			want:   []int64{},
+		},
+		{
+			name:   "zero parts",
+			amount: 1000,
+			parts:  []dr.DateRange{{}, {}},
+			want:   []int64{0, 0},
+		},
+		{
+			name:   "exact",
+			amount: 3000,
+			parts: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 4, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []int64{1000, 2000},
+		},
+		{
+			name:   "plan change mid-cycle",
+			amount: 999,
+			parts: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []int64{322, 677}, // 322.26 and 676.74
+		},
+		{
+			name:   "ties go to the earlier part",
+			amount: 100,
+			parts: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []int64{34, 33, 33},
+		},
+		{
+			name:   "negative amount",
+			amount: -100,
+			parts: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []int64{-33, -67},
+		},
+		{
+			name:   "large amount does not overflow",
+			amount: math.MaxInt64,
+			parts: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []int64{3074457345618258602, 6148914691236517205},
+		},
+		{
+			name:   "smallest amount",
+			amount: math.MinInt64,
+			parts: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []int64{math.MinInt64},
+		},
+		{
+			name:   "unbounded parts take everything",
+			amount: 101,
+			parts: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRangeFrom(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+				dr.NewUnboundedDateRange(),
+			},
+			want: []int64{0, 51, 50},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := dr.Prorate(c.amount, c.parts...)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Prorate(%v, %v) = %v, want %v", c.amount, c.parts, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.ProrateWith
+func TestProrateWith(t *testing.T) {
+	parts := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+	}
+	cases := []struct {
+		name string
+		conv dr.DayCountConvention
+		want []int64
+	}{
+		{
+			name: "actual days",
+			conv: dr.Act360{},
+			want: []int64{5167, 4833}, // 31 and 29 days
+		},
+		{
+			name: "30 days per month",
+			conv: dr.Thirty360E{},
+			want: []int64{5000, 5000},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := dr.ProrateWith(10000, c.conv, parts...)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("ProrateWith(10000, %T, %v) = %v, want %v", c.conv, parts, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRange.ProrateDaily
+func TestDateRangeProrateDaily(t *testing.T) {
+	cases := []struct {
+		name   string
+		d      dr.DateRange
+		amount int64
+		want   []int64
+	}{
+		{
+			name:   "zero range",
+			d:      dr.DateRange{},
+			amount: 100,
+			want:   []int64{},
+		},
+		{
+			name:   "unbounded range",
+			d:      dr.NewDateRangeUntil(time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)),
+			amount: 100,
+			want:   []int64{},
+		},
+		{
+			name:   "week",
+			d:      dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)),
+			amount: 1000,
+			want:   []int64{143, 143, 143, 143, 143, 143, 142},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := c.d.ProrateDaily(c.amount)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("%v.ProrateDaily(%v) = %v, want %v", c.d, c.amount, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.Prorate
+func TestDateRangesProrate(t *testing.T) {
+	drs := dr.NewDateRanges(
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+	)
+	want := []int64{67, 33}
+	if got := drs.Prorate(100); !reflect.DeepEqual(got, want) {
+		t.Errorf("%v.Prorate(100) = %v, want %v", drs, got, want)
+	}
+}
+
+// test that the shares of dr.Prorate always add up to the amount
+func TestProrateSum(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for n := 1; n <= 40; n++ {
+		parts := []dr.DateRange{}
+		for i := 0; i < n; i++ {
+			from := start.AddDate(0, 0, i*i)
+			parts = append(parts, dr.NewDateRange(from, from.AddDate(0, 0, (i*7)%11)))
+		}
+		for _, amount := range []int64{0, 1, -1, 7, 999, -12345, math.MaxInt64, math.MinInt64 + 1} {
+			sum := int64(0)
+			for _, share := range dr.Prorate(amount, parts...) {
+				sum += share
+			}
+			if sum != amount {
+				t.Fatalf("Prorate(%v, %d parts) adds up to %v", amount, n, sum)
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package daterange
+
+import (
+	"sort"
+	"time"
+)
+
+// RateEntry is a nightly rate applying to the nights of a DateRange.
+type RateEntry struct {
+	Range    DateRange // nights the rate applies to
+	Priority int       // entries with a higher priority override lower ones
+	Rate     int64     // price of a night in integer minor units, such as cents
+}
+
+// RateTable is a set of possibly overlapping rate entries, for example a base
+// rate, a high season overriding it and a promotion overriding both.
+// Each night is priced by the entry with the highest priority covering it; among
+// entries of equal priority the earliest one in the table wins.
+type RateTable []RateEntry
+
+// NightlyRate is the price of a single night of a stay.
+type NightlyRate struct {
+	Date  time.Time // the night, as the date of the evening it starts
+	Rate  int64     // price of the night
+	Entry int       // index in the RateTable of the entry pricing the night
+}
+
+// RateQuote is the price of a stay.
+type RateQuote struct {
+	Nights    []NightlyRate // price of every covered night, in ascending order
+	Total     int64         // sum of the prices of all covered nights
+	Uncovered DateRanges    // nights not covered by any entry
+}
+
+// Quote returns the nightly breakdown and the total price of a stay, given as
+// the DateRange of its nights. Nights that no entry covers are not priced and
+// are reported in Uncovered. An empty or unbounded stay gets an empty quote.
+func (t RateTable) Quote(stay DateRange) RateQuote {
+	quote := RateQuote{
+		Nights:    []NightlyRate{},
+		Uncovered: NewDateRanges(),
+	}
+	if stay.IsEmpty() || !stay.IsBounded() {
+		return quote
+	}
+
+	// apply the entries from the highest priority down, every night keeps the first price it gets
+	order := make([]int, 0, len(t))
+	for i, entry := range t {
+		if entry.Range.Overlaps(stay) {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return t[order[i]].Priority > t[order[j]].Priority
+	})
+	entries := make([]int, stay.Days())
+	for i := range entries {
+		entries[i] = -1
+	}
+	for _, i := range order {
+		overlap := t[i].Range.Intersection(stay)
+		first := daysBetween(stay.from, overlap.from)
+		for night := first; night < first+overlap.Days(); night++ {
+			if entries[night] < 0 {
+				entries[night] = i
+			}
+		}
+	}
+
+	uncovered := []DateRange{}
+	for night, i := range entries {
+		date := stay.from.AddDate(0, 0, night)
+		if i < 0 {
+			uncovered = append(uncovered, DateRange{from: date, to: date, nonEmpty: true})
+			continue
+		}
+		quote.Nights = append(quote.Nights, NightlyRate{Date: date, Rate: t[i].Rate, Entry: i})
+		quote.Total += t[i].Rate
+	}
+	quote.Uncovered = NewDateRanges(uncovered...)
+	return quote
+}
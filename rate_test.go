@@ -0,0 +1,93 @@
+package daterange_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// test dr.RateTable.Quote
+func TestRateTableQuote(t *testing.T) {
+	table := dr.RateTable{
+		{Range: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)), Priority: 0, Rate: 10000},
+		{Range: dr.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 8, 31, 0, 0, 0, 0, time.UTC)), Priority: 1, Rate: 15000},
+		{Range: dr.NewDateRange(time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 11, 0, 0, 0, 0, time.UTC)), Priority: 2, Rate: 12000},
+		{Range: dr.NewDateRange(time.Date(2024, 7, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC)), Priority: 2, Rate: 11000},
+	}
+	cases := []struct {
+		name          string
+		stay          dr.DateRange
+		wantNights    []dr.NightlyRate
+		wantTotal     int64
+		wantUncovered []dr.DateRange
+	}{
+		{
+			name:          "zero stay",
+			stay:          dr.DateRange{},
+			wantNights:    []dr.NightlyRate{},
+			wantTotal:     0,
+			wantUncovered: []dr.DateRange{},
+		},
+		{
+			name: "base rate",
+			stay: dr.NewDateRange(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)),
+			wantNights: []dr.NightlyRate{
+				{Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Rate: 10000, Entry: 0},
+				{Date: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), Rate: 10000, Entry: 0},
+			},
+			wantTotal:     20000,
+			wantUncovered: []dr.DateRange{},
+		},
+		{
+			name: "high season overrides base",
+			stay: dr.NewDateRange(time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)),
+			wantNights: []dr.NightlyRate{
+				{Date: time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), Rate: 10000, Entry: 0},
+				{Date: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), Rate: 15000, Entry: 1},
+				{Date: time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC), Rate: 15000, Entry: 1},
+			},
+			wantTotal:     40000,
+			wantUncovered: []dr.DateRange{},
+		},
+		{
+			name: "equal priorities, earliest entry wins",
+			stay: dr.NewDateRange(time.Date(2024, 7, 9, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC)),
+			wantNights: []dr.NightlyRate{
+				{Date: time.Date(2024, 7, 9, 0, 0, 0, 0, time.UTC), Rate: 15000, Entry: 1},
+				{Date: time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC), Rate: 12000, Entry: 2},
+				{Date: time.Date(2024, 7, 11, 0, 0, 0, 0, time.UTC), Rate: 12000, Entry: 2},
+				{Date: time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC), Rate: 11000, Entry: 3},
+				{Date: time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC), Rate: 15000, Entry: 1},
+			},
+			wantTotal:     65000,
+			wantUncovered: []dr.DateRange{},
+		},
+		{
+			name: "uncovered nights",
+			stay: dr.NewDateRange(time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)),
+			wantNights: []dr.NightlyRate{
+				{Date: time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), Rate: 10000, Entry: 0},
+				{Date: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), Rate: 10000, Entry: 0},
+			},
+			wantTotal:     20000,
+			wantUncovered: []dr.DateRange{dr.NewDateRange(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC))},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := table.Quote(c.stay)
+			if !reflect.DeepEqual(got.Nights, c.wantNights) {
+				t.Errorf("Quote(%v).Nights = %v, want %v", c.stay, got.Nights, c.wantNights)
+			}
+			if got.Total != c.wantTotal {
+				t.Errorf("Quote(%v).Total = %v, want %v", c.stay, got.Total, c.wantTotal)
+			}
+			if !reflect.DeepEqual(got.Uncovered.ToSlice(), c.wantUncovered) {
+				t.Errorf("Quote(%v).Uncovered = %v, want %v", c.stay, got.Uncovered, c.wantUncovered)
+			}
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package daterange
+
+import (
+	"sort"
+	"time"
+)
+
+// Frequency is the base unit of a Recurrence, modeled on the RFC 5545 FREQ rule part.
+type Frequency int
+
+const (
+	// Daily repeats every Interval days.
+	Daily Frequency = iota + 1
+	// Weekly repeats every Interval weeks. Weeks start on Monday.
+	Weekly
+	// Monthly repeats every Interval months.
+	Monthly
+	// Yearly repeats every Interval years.
+	Yearly
+)
+
+// WeekdayNum is a BYDAY entry of a Recurrence. N selects the Nth occurrence of
+// the weekday within the month (Monthly) or the year (Yearly); negative values
+// count from the end, 0 means every occurrence. N is ignored for Daily and Weekly.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// Recurrence is a recurring set of date ranges modeled on the RFC 5545 RRULE.
+// Every occurrence starts on a date generated by the rule and lasts Duration days.
+//
+// For example "first weekend of each month" is
+//
+//	Recurrence{Start: start, Freq: Monthly, ByDay: []WeekdayNum{{time.Saturday, 1}}, Duration: 2}
+//
+// and "every 2nd Tuesday for 3 days" is
+//
+//	Recurrence{Start: start, Freq: Monthly, ByDay: []WeekdayNum{{time.Tuesday, 2}}, Duration: 3}
+//
+// Note: Only the date portion of the time.Time values is compared. The time portion is ignored.
+type Recurrence struct {
+	Start      time.Time    // DTSTART, no occurrence starts before this date, required
+	Freq       Frequency    // FREQ
+	Interval   int          // INTERVAL, values less than 1 are treated as 1
+	ByDay      []WeekdayNum // BYDAY
+	ByMonthDay []int        // BYMONTHDAY, 1 to 31 or -31 to -1 counting from the end of the month
+	BySetPos   []int        // BYSETPOS, 1-based, negative values count from the end of the set
+	Count      int          // COUNT, 0 means no limit
+	Until      *time.Time   // UNTIL, inclusive, nil means no limit
+	Duration   int          // length of every occurrence in days, values less than 1 are treated as 1
+	Exclude    []time.Time  // EXDATE, occurrences starting on these dates are skipped
+}
+
+// Expand returns the occurrences that overlap the given window, clipped to the window.
+// As with RFC 5545 EXDATE, excluded occurrences still count towards Count.
+// Occurrences that overlap or are adjacent are merged in the returned collection.
+// A window with no end returns an empty collection unless Count or Until is set.
+//
+// A rule with an out of range BYxxx value, such as a BYMONTHDAY of 0 or 32,
+// returns an empty collection. As the calendar repeats every 400 years, the
+// expansion also stops once the rule has generated no date for that long.
+func (r Recurrence) Expand(window DateRange) DateRanges {
+	if window.IsEmpty() || !r.valid() {
+		return NewDateRanges()
+	}
+	if window.IsToInf() && r.Count < 1 && r.Until == nil {
+		return NewDateRanges()
+	}
+
+	start := toDateUTC(r.Start)
+	until := posInf
+	if r.Until != nil {
+		until = toDateUTC(*r.Until)
+	}
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	// a single step of the largest interval leaves the supported dates,
+	// larger intervals would only overflow
+	if maxInterval := r.maxInterval(); interval > maxInterval {
+		interval = maxInterval
+	}
+	duration := r.Duration
+	if duration < 1 {
+		duration = 1
+	}
+	excluded := make(map[time.Time]bool, len(r.Exclude))
+	for _, date := range r.Exclude {
+		excluded[toDateUTC(date)] = true
+	}
+
+	occurrences := []DateRange{}
+	count := 0
+	empty := 0 // consecutive periods without any date
+	maxEmpty := r.cyclePeriods()
+periods:
+	for period := r.periodStart(start); !period.After(window.to); period = r.nextPeriod(period, interval) {
+		if empty++; empty > maxEmpty {
+			break
+		}
+		for _, date := range r.candidates(period, start) {
+			if date.Before(start) {
+				continue
+			}
+			empty = 0
+			if date.After(until) {
+				break periods
+			}
+			if r.Count > 0 && count >= r.Count {
+				break periods
+			}
+			count++
+			if excluded[date] {
+				continue
+			}
+			occurrence := DateRange{
+				from:     date,
+				to:       date.AddDate(0, 0, duration-1),
+				nonEmpty: true,
+			}
+			if occurrence.Overlaps(window) {
+				occurrences = append(occurrences, occurrence.Intersection(window))
+			}
+		}
+	}
+	return NewDateRanges(occurrences...)
+}
+
+// valid returns true if the frequency and all the BYxxx values are in range.
+func (r Recurrence) valid() bool {
+	if r.Freq < Daily || r.Freq > Yearly {
+		return false
+	}
+	for _, day := range r.ByMonthDay {
+		if day == 0 || day < -31 || day > 31 {
+			return false
+		}
+	}
+	for _, wd := range r.ByDay {
+		if wd.Weekday < time.Sunday || wd.Weekday > time.Saturday || wd.N < -53 || wd.N > 53 {
+			return false
+		}
+	}
+	for _, pos := range r.BySetPos {
+		if pos == 0 || pos < -366 || pos > 366 {
+			return false
+		}
+	}
+	return true
+}
+
+// cyclePeriods returns the number of periods of the frequency in the 400 years
+// after which the Gregorian calendar repeats. A rule that generates no date in
+// that many consecutive periods never generates one.
+func (r Recurrence) cyclePeriods() int {
+	switch r.Freq {
+	case Weekly:
+		return 146097 / 7
+	case Monthly:
+		return 400 * 12
+	case Yearly:
+		return 400
+	}
+	return 146097
+}
+
+// maxInterval returns the number of periods of the frequency between the
+// infinite bounds.
+func (r Recurrence) maxInterval() int {
+	years := posInf.Year() - negInf.Year() + 1
+	switch r.Freq {
+	case Weekly:
+		return daysBetween(negInf, posInf)/7 + 1
+	case Monthly:
+		return 12 * years
+	case Yearly:
+		return years
+	}
+	return daysBetween(negInf, posInf) + 1
+}
+
+// periodStart returns the first day of the period containing the given date.
+func (r Recurrence) periodStart(date time.Time) time.Time {
+	switch r.Freq {
+	case Weekly:
+		return date.AddDate(0, 0, -mondayOffset(date.Weekday()))
+	case Monthly:
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case Yearly:
+		return time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return date
+}
+
+// nextPeriod returns the first day of the period interval periods after the given one.
+func (r Recurrence) nextPeriod(period time.Time, interval int) time.Time {
+	switch r.Freq {
+	case Weekly:
+		return period.AddDate(0, 0, 7*interval)
+	case Monthly:
+		return period.AddDate(0, interval, 0)
+	case Yearly:
+		return period.AddDate(interval, 0, 0)
+	}
+	return period.AddDate(0, 0, interval)
+}
+
+// candidates returns the sorted occurrence dates generated within the given period,
+// after applying the BYxxx rule parts.
+func (r Recurrence) candidates(period, start time.Time) []time.Time {
+	var dates []time.Time
+	switch r.Freq {
+	case Daily:
+		dates = []time.Time{period}
+	case Weekly:
+		if len(r.ByDay) == 0 {
+			dates = []time.Time{period.AddDate(0, 0, mondayOffset(start.Weekday()))}
+		}
+		for _, wd := range r.ByDay {
+			dates = append(dates, period.AddDate(0, 0, mondayOffset(wd.Weekday)))
+		}
+	case Monthly:
+		end := period.AddDate(0, 1, -1)
+		switch {
+		case len(r.ByMonthDay) > 0:
+			dates = monthDays(period, r.ByMonthDay)
+		case len(r.ByDay) > 0:
+			dates = nthWeekdays(period, end, r.ByDay)
+		default:
+			dates = monthDays(period, []int{start.Day()})
+		}
+	case Yearly:
+		end := period.AddDate(1, 0, -1)
+		switch {
+		case len(r.ByMonthDay) > 0:
+			for month := period; month.Before(end); month = month.AddDate(0, 1, 0) {
+				dates = append(dates, monthDays(month, r.ByMonthDay)...)
+			}
+		case len(r.ByDay) > 0:
+			dates = nthWeekdays(period, end, r.ByDay)
+		default:
+			date := time.Date(period.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+			if date.Month() == start.Month() {
+				dates = []time.Time{date}
+			}
+		}
+	}
+
+	dates = r.filter(dates)
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+	dates = uniqueDates(dates)
+	return selectPositions(dates, r.BySetPos)
+}
+
+// filter keeps the dates matching the BYDAY and BYMONTHDAY rule parts that
+// limit, rather than expand, the given frequency.
+func (r Recurrence) filter(dates []time.Time) []time.Time {
+	limitByDay := len(r.ByDay) > 0 && (r.Freq == Daily || len(r.ByMonthDay) > 0)
+	limitByMonthDay := len(r.ByMonthDay) > 0 && (r.Freq == Daily || r.Freq == Weekly)
+	if !limitByDay && !limitByMonthDay {
+		return dates
+	}
+	filtered := dates[:0]
+	for _, date := range dates {
+		if limitByDay && !matchesWeekday(date, r.ByDay) {
+			continue
+		}
+		if limitByMonthDay && !matchesMonthDay(date, r.ByMonthDay) {
+			continue
+		}
+		filtered = append(filtered, date)
+	}
+	return filtered
+}
+
+// mondayOffset returns the number of days from Monday to the given weekday.
+func mondayOffset(wd time.Weekday) int {
+	return (int(wd) + 6) % 7
+}
+
+// matchesWeekday returns true if the weekday of the date is one of the given weekdays.
+func matchesWeekday(date time.Time, weekdays []WeekdayNum) bool {
+	for _, wd := range weekdays {
+		if date.Weekday() == wd.Weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMonthDay returns true if the date is one of the given days of its month.
+func matchesMonthDay(date time.Time, days []int) bool {
+	for _, candidate := range monthDays(date, days) {
+		if candidate.Equal(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// monthDays returns the dates of the month of the given date matching the
+// given days of month. Negative days count from the end of the month.
+func monthDays(date time.Time, days []int) []time.Time {
+	first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	dates := []time.Time{}
+	for _, day := range days {
+		if day < 0 {
+			day = last + day + 1
+		}
+		if day < 1 || day > last {
+			continue
+		}
+		dates = append(dates, first.AddDate(0, 0, day-1))
+	}
+	return dates
+}
+
+// nthWeekdays returns the dates between from and to (inclusive) matching the
+// given weekdays, honoring the ordinal of every WeekdayNum.
+func nthWeekdays(from, to time.Time, weekdays []WeekdayNum) []time.Time {
+	dates := []time.Time{}
+	for _, wd := range weekdays {
+		first := from.AddDate(0, 0, (int(wd.Weekday)-int(from.Weekday())+7)%7)
+		all := []time.Time{}
+		for date := first; !date.After(to); date = date.AddDate(0, 0, 7) {
+			all = append(all, date)
+		}
+		if wd.N == 0 {
+			dates = append(dates, all...)
+			continue
+		}
+		dates = append(dates, selectPositions(all, []int{wd.N})...)
+	}
+	return dates
+}
+
+// selectPositions returns the dates at the given 1-based positions of a sorted
+// slice, negative positions counting from the end. It returns all the dates if
+// no position is given.
+func selectPositions(dates []time.Time, positions []int) []time.Time {
+	if len(positions) == 0 {
+		return dates
+	}
+	selected := []time.Time{}
+	for _, pos := range positions {
+		idx := pos - 1
+		if pos < 0 {
+			idx = len(dates) + pos
+		}
+		if pos == 0 || idx < 0 || idx >= len(dates) {
+			continue
+		}
+		selected = append(selected, dates[idx])
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].Before(selected[j])
+	})
+	return uniqueDates(selected)
+}
+
+// uniqueDates removes consecutive duplicates from a sorted slice of dates.
+func uniqueDates(dates []time.Time) []time.Time {
+	if len(dates) < 2 {
+		return dates
+	}
+	unique := dates[:1]
+	for _, date := range dates[1:] {
+		if !date.Equal(unique[len(unique)-1]) {
+			unique = append(unique, date)
+		}
+	}
+	return unique
+}
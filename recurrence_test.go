@@ -0,0 +1,296 @@
+package daterange_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// test dr.Recurrence.Expand
+func TestRecurrenceExpand(t *testing.T) {
+	cases := []struct {
+		name   string
+		r      dr.Recurrence
+		window dr.DateRange
+		want   []dr.DateRange
+	}{
+		{
+			name:   "zero window",
+			r:      dr.Recurrence{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Freq: dr.Daily},
+			window: dr.DateRange{},
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "no end without limit",
+			r:      dr.Recurrence{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Freq: dr.Daily},
+			window: dr.NewDateRangeFrom(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "no end with count",
+			r:      dr.Recurrence{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Freq: dr.Monthly, Count: 2},
+			window: dr.NewUnboundedDateRange(),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:   "start on the zero time",
+			r:      dr.Recurrence{Start: time.Time{}, Freq: dr.Daily, Count: 3},
+			window: dr.NewDateRange(time.Time{}, time.Date(1, 1, 10, 0, 0, 0, 0, time.UTC)),
+			want:   []dr.DateRange{dr.NewDateRange(time.Time{}, time.Date(1, 1, 3, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:   "out of range month day",
+			r:      dr.Recurrence{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Freq: dr.Monthly, ByMonthDay: []int{32}, Count: 1},
+			window: dr.NewDateRangeFrom(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "out of range weekday ordinal",
+			r:      dr.Recurrence{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Freq: dr.Yearly, ByDay: []dr.WeekdayNum{{Weekday: time.Monday, N: 54}}, Count: 1},
+			window: dr.NewDateRangeFrom(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "rule that never generates a date",
+			r:      dr.Recurrence{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Freq: dr.Daily, Interval: 7, ByDay: []dr.WeekdayNum{{Weekday: time.Friday}}, Count: 1},
+			window: dr.NewUnboundedDateRange(),
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "rare dates after long gaps",
+			r:      dr.Recurrence{Start: time.Date(2096, 2, 29, 0, 0, 0, 0, time.UTC), Freq: dr.Yearly, Count: 2},
+			window: dr.NewDateRangeFrom(time.Date(2096, 1, 1, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2096, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2096, 2, 29, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2104, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2104, 2, 29, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:   "huge interval",
+			r:      dr.Recurrence{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Freq: dr.Weekly, Interval: math.MaxInt, Count: 2},
+			window: dr.NewDateRangeFrom(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			want:   []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:   "invalid frequency",
+			r:      dr.Recurrence{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			window: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "daily interval 3",
+			r:      dr.Recurrence{Start: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), Freq: dr.Daily, Interval: 3},
+			window: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "daily by day filter",
+			r: dr.Recurrence{
+				Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Freq:  dr.Daily,
+				ByDay: []dr.WeekdayNum{{Weekday: time.Saturday}, {Weekday: time.Sunday}},
+			},
+			window: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "every other tuesday for 3 days",
+			r: dr.Recurrence{
+				Start:    time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				Freq:     dr.Weekly,
+				Interval: 2,
+				Duration: 3,
+			},
+			window: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 18, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "weekly by day skips dates before start",
+			r: dr.Recurrence{
+				Start: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+				Freq:  dr.Weekly,
+				ByDay: []dr.WeekdayNum{{Weekday: time.Monday}, {Weekday: time.Friday}},
+				Count: 3,
+			},
+			window: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "second tuesday of each month for 3 days",
+			r: dr.Recurrence{
+				Start:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Freq:     dr.Monthly,
+				ByDay:    []dr.WeekdayNum{{Weekday: time.Tuesday, N: 2}},
+				Duration: 3,
+			},
+			window: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "first weekend of each month",
+			r: dr.Recurrence{
+				Start:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Freq:     dr.Monthly,
+				ByDay:    []dr.WeekdayNum{{Weekday: time.Saturday, N: 1}},
+				Duration: 2,
+			},
+			window: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "last working day of each month",
+			r: dr.Recurrence{
+				Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Freq:  dr.Monthly,
+				ByDay: []dr.WeekdayNum{
+					{Weekday: time.Monday}, {Weekday: time.Tuesday}, {Weekday: time.Wednesday},
+					{Weekday: time.Thursday}, {Weekday: time.Friday},
+				},
+				BySetPos: []int{-1},
+			},
+			window: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:   "monthly on the 31st skips short months",
+			r:      dr.Recurrence{Start: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), Freq: dr.Monthly},
+			window: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "daily, until the zero time",
+			r: dr.Recurrence{
+				Start: time.Time{},
+				Freq:  dr.Daily,
+				Until: datePtr(time.Time{}),
+			},
+			window: dr.NewDateRangeFrom(time.Time{}),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Time{}, time.Time{}),
+			},
+		},
+		{
+			name: "monthly by month day from end, until",
+			r: dr.Recurrence{
+				Start:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Freq:       dr.Monthly,
+				ByMonthDay: []int{-1},
+				Until:      datePtr(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+			},
+			window: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "friday the 13th",
+			r: dr.Recurrence{
+				Start:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Freq:       dr.Monthly,
+				ByDay:      []dr.WeekdayNum{{Weekday: time.Friday}},
+				ByMonthDay: []int{13},
+			},
+			window: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 12, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 13, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:   "yearly on leap day",
+			r:      dr.Recurrence{Start: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC), Freq: dr.Yearly},
+			window: dr.NewDateRange(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "yearly last monday of the year",
+			r: dr.Recurrence{
+				Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				Freq:  dr.Yearly,
+				ByDay: []dr.WeekdayNum{{Weekday: time.Monday, N: -1}},
+			},
+			window: dr.NewDateRange(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC), time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "exclusions count towards count",
+			r: dr.Recurrence{
+				Start:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Freq:    dr.Weekly,
+				Count:   3,
+				Exclude: []time.Time{time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)},
+			},
+			window: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name: "occurrence started before window is clipped",
+			r: dr.Recurrence{
+				Start:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Freq:     dr.Weekly,
+				Duration: 4,
+			},
+			window: dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)),
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := c.r.Expand(c.window)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("%+v.Expand(%v) = %v, want %v", c.r, c.window, got, c.want)
+			}
+		})
+	}
+}
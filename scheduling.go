@@ -0,0 +1,120 @@
+package daterange
+
+import (
+	"container/heap"
+	"sort"
+	"time"
+)
+
+// MaxNonOverlapping returns the indices of a largest subset of the given ranges
+// in which no two ranges overlap, as defined by DateRange.Overlaps, for example
+// the most bookings a single resource can host. Adjacent ranges do not overlap.
+// Empty ranges are never selected. The indices are returned in ascending order
+// of the dates of their ranges.
+//
+// It uses the greedy earliest end first algorithm, ties going to the lower index,
+// and runs in O(n log n).
+func MaxNonOverlapping(ranges []DateRange) []int {
+	order := sortedIndices(ranges, func(a, b DateRange) bool {
+		return a.to.Before(b.to)
+	})
+	selected := []int{}
+	var lastEnd time.Time
+	for _, i := range order {
+		if len(selected) == 0 || ranges[i].from.After(lastEnd) {
+			selected = append(selected, i)
+			lastEnd = ranges[i].to
+		}
+	}
+	return selected
+}
+
+// MinResources returns the minimum number of resources needed to host all the
+// given ranges, so that no resource hosts two overlapping ranges, and an
+// assignment of every range to a resource numbered from 0. Empty ranges are
+// assigned to resource -1.
+//
+// It sweeps the ranges in order of their first date, giving each one the
+// lowest numbered free resource, and runs in O(n log n).
+func MinResources(ranges []DateRange) (int, []int) {
+	assignment := make([]int, len(ranges))
+	for i := range assignment {
+		assignment[i] = -1
+	}
+	order := sortedIndices(ranges, func(a, b DateRange) bool {
+		return a.from.Before(b.from)
+	})
+
+	busy := &endHeap{}      // ranges being hosted, by last date
+	free := &resourceHeap{} // released resources, by number
+	count := 0
+	for _, i := range order {
+		// release the resources of the ranges ending before this one starts
+		for busy.Len() > 0 && (*busy)[0].end.Before(ranges[i].from) {
+			heap.Push(free, assignment[heap.Pop(busy).(endItem).index])
+		}
+		resource := count
+		if free.Len() > 0 {
+			resource = heap.Pop(free).(int)
+		} else {
+			count++
+		}
+		assignment[i] = resource
+		heap.Push(busy, endItem{end: ranges[i].to, index: i})
+	}
+	return count, assignment
+}
+
+// sortedIndices returns the indices of the non-empty ranges sorted by the given
+// less function, ties keeping the lower index first.
+func sortedIndices(ranges []DateRange, less func(a, b DateRange) bool) []int {
+	order := make([]int, 0, len(ranges))
+	for i, dr := range ranges {
+		if !dr.IsEmpty() {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return less(ranges[order[i]], ranges[order[j]])
+	})
+	return order
+}
+
+// endItem is a range in an endHeap.
+type endItem struct {
+	end   time.Time
+	index int
+}
+
+// endHeap is a min-heap of ranges by last date, implementing heap.Interface.
+type endHeap []endItem
+
+func (h endHeap) Len() int { return len(h) }
+func (h endHeap) Less(i, j int) bool {
+	if h[i].end.Equal(h[j].end) {
+		return h[i].index < h[j].index
+	}
+	return h[i].end.Before(h[j].end)
+}
+func (h endHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *endHeap) Push(x interface{}) { *h = append(*h, x.(endItem)) }
+func (h *endHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// resourceHeap is a min-heap of resource numbers, implementing heap.Interface.
+type resourceHeap []int
+
+func (h resourceHeap) Len() int            { return len(h) }
+func (h resourceHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h resourceHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *resourceHeap) Push(x interface{}) { *h = append(*h, x.(int)) }
+func (h *resourceHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
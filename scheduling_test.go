@@ -0,0 +1,184 @@
+package daterange_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// test dr.MaxNonOverlapping
+func TestMaxNonOverlapping(t *testing.T) {
+	cases := []struct {
+		name   string
+		ranges []dr.DateRange
+		want   []int
+	}{
+		{
+			name:   "empty",
+			ranges: []dr.DateRange{},
+			want:   []int{},
+		},
+		{
+			name:   "zero ranges are skipped",
+			ranges: []dr.DateRange{{}, dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+			want:   []int{1},
+		},
+		{
+			name: "long range loses to short ones",
+			ranges: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []int{1, 2, 4},
+		},
+		{
+			name: "ties go to the lower index",
+			ranges: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+			},
+			want: []int{0},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := dr.MaxNonOverlapping(c.ranges)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("MaxNonOverlapping(%v) = %v, want %v", c.ranges, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.MinResources
+func TestMinResources(t *testing.T) {
+	cases := []struct {
+		name           string
+		ranges         []dr.DateRange
+		wantCount      int
+		wantAssignment []int
+	}{
+		{
+			name:           "empty",
+			ranges:         []dr.DateRange{},
+			wantCount:      0,
+			wantAssignment: []int{},
+		},
+		{
+			name:           "zero range",
+			ranges:         []dr.DateRange{{}},
+			wantCount:      0,
+			wantAssignment: []int{-1},
+		},
+		{
+			name: "adjacent ranges share a resource",
+			ranges: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+			},
+			wantCount:      1,
+			wantAssignment: []int{0, 0},
+		},
+		{
+			name: "released resources are reused lowest first",
+			ranges: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)),
+			},
+			wantCount:      3,
+			wantAssignment: []int{0, 1, 2, 1, 2, 0},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			count, assignment := dr.MinResources(c.ranges)
+			if count != c.wantCount || !reflect.DeepEqual(assignment, c.wantAssignment) {
+				t.Errorf("MinResources(%v) = %v, %v, want %v, %v", c.ranges, count, assignment, c.wantCount, c.wantAssignment)
+			}
+		})
+	}
+}
+
+// test dr.MaxNonOverlapping and dr.MinResources against brute force on random ranges
+func TestSchedulingRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for round := 0; round < 200; round++ {
+		ranges := make([]dr.DateRange, rnd.Intn(10))
+		for i := range ranges {
+			from := start.AddDate(0, 0, rnd.Intn(30))
+			ranges[i] = dr.NewDateRange(from, from.AddDate(0, 0, rnd.Intn(7)))
+		}
+
+		// the largest subset without overlaps, by brute force
+		best := 0
+		for mask := 0; mask < 1<<len(ranges); mask++ {
+			ok, size := true, 0
+			for i := range ranges {
+				if mask&(1<<i) == 0 {
+					continue
+				}
+				size++
+				for j := i + 1; j < len(ranges); j++ {
+					if mask&(1<<j) != 0 && ranges[i].Overlaps(ranges[j]) {
+						ok = false
+					}
+				}
+			}
+			if ok && size > best {
+				best = size
+			}
+		}
+		selected := dr.MaxNonOverlapping(ranges)
+		if len(selected) != best {
+			t.Fatalf("MaxNonOverlapping(%v) = %v, want %d ranges", ranges, selected, best)
+		}
+		for i := range selected {
+			for j := i + 1; j < len(selected); j++ {
+				if ranges[selected[i]].Overlaps(ranges[selected[j]]) {
+					t.Fatalf("MaxNonOverlapping(%v) = %v selects overlapping ranges", ranges, selected)
+				}
+			}
+		}
+
+		// the most ranges sharing a date is the minimum number of resources
+		depth := 0
+		for day := 0; day < 40; day++ {
+			n := 0
+			for _, r := range ranges {
+				if r.Contains(start.AddDate(0, 0, day)) {
+					n++
+				}
+			}
+			if n > depth {
+				depth = n
+			}
+		}
+		count, assignment := dr.MinResources(ranges)
+		if count != depth {
+			t.Fatalf("MinResources(%v) = %v, want %v", ranges, count, depth)
+		}
+		for i := range ranges {
+			if assignment[i] < 0 || assignment[i] >= count {
+				t.Fatalf("MinResources(%v) assigns %v to %v", ranges, ranges[i], assignment[i])
+			}
+			for j := i + 1; j < len(ranges); j++ {
+				if assignment[i] == assignment[j] && ranges[i].Overlaps(ranges[j]) {
+					t.Fatalf("MinResources(%v) assigns overlapping %v and %v to %v", ranges, ranges[i], ranges[j], assignment[i])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package daterange
+
+import "time"
+
+// SlotQuery describes the free slots searched by DateRanges.FirstFreeSlot and
+// DateRanges.FreeSlots, where the collection holds the occupied dates.
+type SlotQuery struct {
+	Days    int            // number of consecutive free days of every slot
+	Bounds  DateRange      // every slot is within these dates
+	After   *time.Time     // slots start on or after this date, nil means the start of Bounds
+	CheckIn []time.Weekday // allowed weekdays of the first day of a slot, empty means any
+	Horizon int            // slots start within this number of days of the search start, 0 means no limit
+}
+
+// FirstFreeSlot returns the earliest slot matching the query whose dates are
+// all free, i.e. not in the collection. It returns false if there is none.
+func (drs *DateRanges) FirstFreeSlot(q SlotQuery) (DateRange, bool) {
+	slots := drs.FreeSlots(q, 1)
+	if len(slots) == 0 {
+		return DateRange{}, false
+	}
+	return slots[0], true
+}
+
+// FreeSlots returns the k earliest slots matching the query whose dates are all
+// free, i.e. not in the collection, in ascending order of their first date.
+// Every possible first date gives a slot, so consecutive slots can overlap.
+// A k less than 1 returns all the matching slots.
+//
+// The search needs a start, from Bounds or After. If it also has no end, from
+// Bounds or Horizon, k must be at least 1, otherwise no slot is returned.
+func (drs *DateRanges) FreeSlots(q SlotQuery, k int) []DateRange {
+	slots := []DateRange{}
+	if q.Days < 1 || q.Bounds.IsEmpty() {
+		return slots
+	}
+	search := q.Bounds
+	if q.After != nil {
+		search.from = maxTime(search.from, toDateUTC(*q.After))
+	}
+	if search.from.After(search.to) || search.IsFromInf() {
+		return slots
+	}
+	// latest first date allowed by the horizon
+	lastStart := search.to
+	if q.Horizon > 0 {
+		lastStart = minTime(lastStart, addDays(search.from, q.Horizon-1))
+	}
+	if isPosInf(lastStart) && k < 1 {
+		return slots
+	}
+	allowed := map[time.Weekday]bool{}
+	for _, wd := range q.CheckIn {
+		allowed[wd] = true
+	}
+
+	free := drs.Complement(search)
+	for _, gap := range free.dr {
+		if q.Days > gap.Days() {
+			continue
+		}
+		for start := gap.from; !start.After(lastStart); start = start.AddDate(0, 0, 1) {
+			// a slot always ends on a finite date within the gap
+			if q.Days-1 > daysBetween(start, lastDate) {
+				break
+			}
+			end := start.AddDate(0, 0, q.Days-1)
+			if end.Before(start) || end.After(gap.to) {
+				break
+			}
+			if len(allowed) > 0 && !allowed[start.Weekday()] {
+				continue
+			}
+			slots = append(slots, DateRange{from: start, to: end, nonEmpty: true})
+			if len(slots) == k {
+				return slots
+			}
+		}
+	}
+	return slots
+}
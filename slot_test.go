@@ -0,0 +1,155 @@
+package daterange_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// test dr.DateRanges.FreeSlots
+func TestDateRangesFreeSlots(t *testing.T) {
+	occupied := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 7, 9, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC)),
+	}
+	july := dr.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC))
+	cases := []struct {
+		name     string
+		occupied []dr.DateRange
+		q        dr.SlotQuery
+		k        int
+		want     []dr.DateRange
+	}{
+		{
+			name:     "invalid days",
+			occupied: occupied,
+			q:        dr.SlotQuery{Days: 0, Bounds: july},
+			want:     []dr.DateRange{},
+		},
+		{
+			name:     "huge days",
+			occupied: occupied,
+			q:        dr.SlotQuery{Days: math.MaxInt, Bounds: july},
+			k:        1,
+			want:     []dr.DateRange{},
+		},
+		{
+			name:     "huge days, no end",
+			occupied: occupied,
+			q:        dr.SlotQuery{Days: math.MaxInt - 1, Bounds: dr.NewDateRangeFrom(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC))},
+			k:        1,
+			want:     []dr.DateRange{},
+		},
+		{
+			name:     "zero bounds",
+			occupied: occupied,
+			q:        dr.SlotQuery{Days: 2},
+			want:     []dr.DateRange{},
+		},
+		{
+			name:     "no end",
+			occupied: occupied,
+			q:        dr.SlotQuery{Days: 4, Bounds: dr.NewDateRangeFrom(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC))},
+			k:        2,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 16, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 7, 14, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 17, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "no end, all slots within the horizon",
+			occupied: occupied,
+			q:        dr.SlotQuery{Days: 4, Bounds: dr.NewUnboundedDateRange(), After: datePtr(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)), Horizon: 14},
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 16, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 7, 14, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 17, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "no start",
+			occupied: occupied,
+			q:        dr.SlotQuery{Days: 4, Bounds: dr.NewDateRangeUntil(time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC))},
+			k:        1,
+			want:     []dr.DateRange{},
+		},
+		{
+			name:     "nothing occupied",
+			occupied: []dr.DateRange{},
+			q:        dr.SlotQuery{Days: 3, Bounds: july},
+			k:        2,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "skips gaps that are too short",
+			occupied: occupied,
+			q:        dr.SlotQuery{Days: 3, Bounds: july},
+			k:        3,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 7, 14, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 16, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "after a date",
+			occupied: occupied,
+			q:        dr.SlotQuery{Days: 2, Bounds: july, After: datePtr(time.Date(2024, 7, 7, 0, 0, 0, 0, time.UTC))},
+			k:        2,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 7, 7, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 14, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "check-in on Saturday",
+			occupied: occupied,
+			q:        dr.SlotQuery{Days: 7, Bounds: july, CheckIn: []time.Weekday{time.Saturday}},
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 19, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 7, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 26, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "horizon",
+			occupied: occupied,
+			q:        dr.SlotQuery{Days: 3, Bounds: july, Horizon: 14},
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 7, 14, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 16, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:     "slot must fit within bounds",
+			occupied: occupied,
+			q:        dr.SlotQuery{Days: 3, Bounds: july, After: datePtr(time.Date(2024, 7, 29, 0, 0, 0, 0, time.UTC))},
+			want:     []dr.DateRange{dr.NewDateRange(time.Date(2024, 7, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:     "after the bounds",
+			occupied: occupied,
+			q:        dr.SlotQuery{Days: 1, Bounds: july, After: datePtr(time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC))},
+			want:     []dr.DateRange{},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(c.occupied...)
+			got := drs.FreeSlots(c.q, c.k)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("FreeSlots(%+v, %v) = %v, want %v", c.q, c.k, got, c.want)
+			}
+			first, ok := drs.FirstFreeSlot(c.q)
+			if ok != (len(c.want) > 0) || (ok && first != c.want[0]) {
+				t.Errorf("FirstFreeSlot(%+v) = %v, %v", c.q, first, ok)
+			}
+		})
+	}
+}
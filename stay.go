@@ -0,0 +1,133 @@
+package daterange
+
+import (
+	"fmt"
+	"time"
+)
+
+// StayRule restricts the stays arriving or departing within a season, as used
+// in hotel and rental bookings. A stay is the DateRange of its nights, so it
+// arrives on its first date and departs on the day after its last date.
+//
+// MinNights, MaxNights and ArrivalDays apply to the stays arriving on a date of
+// Season, DepartureDays to the stays departing on a date of Season. An empty
+// Season applies to every date.
+type StayRule struct {
+	Season        DateRanges     // dates the rule applies to
+	MinNights     int            // minimum number of nights, 0 means no minimum
+	MaxNights     int            // maximum number of nights, 0 means no maximum
+	ArrivalDays   []time.Weekday // allowed arrival weekdays, empty means any
+	DepartureDays []time.Weekday // allowed departure weekdays, empty means any
+}
+
+// StayRules is a set of rules that must all be satisfied by a stay.
+type StayRules []StayRule
+
+// StayViolationKind is the restriction of a StayRule broken by a stay.
+type StayViolationKind int
+
+const (
+	// MinNightsViolation is a stay shorter than MinNights.
+	MinNightsViolation StayViolationKind = iota + 1
+	// MaxNightsViolation is a stay longer than MaxNights.
+	MaxNightsViolation
+	// ArrivalDayViolation is an arrival on a weekday not in ArrivalDays.
+	ArrivalDayViolation
+	// DepartureDayViolation is a departure on a weekday not in DepartureDays.
+	DepartureDayViolation
+)
+
+// StayViolation explains why a stay breaks a StayRule.
+type StayViolation struct {
+	Rule  int               // index of the broken rule in StayRules
+	Kind  StayViolationKind // broken restriction
+	Stay  DateRange         // nights of the stay
+	Limit int               // MinNights or MaxNights of the rule, 0 for weekday violations
+}
+
+// Error returns a description of the violation.
+func (v StayViolation) Error() string {
+	arrival := v.Stay.from.Format("2006-01-02")
+	departure := v.Stay.to.AddDate(0, 0, 1)
+	switch v.Kind {
+	case MinNightsViolation:
+		return fmt.Sprintf("stay arriving %s: %d nights, rule %d requires at least %d", arrival, v.Stay.Days(), v.Rule, v.Limit)
+	case MaxNightsViolation:
+		return fmt.Sprintf("stay arriving %s: %d nights, rule %d allows at most %d", arrival, v.Stay.Days(), v.Rule, v.Limit)
+	case ArrivalDayViolation:
+		return fmt.Sprintf("stay arriving %s: rule %d does not allow arrivals on %s", arrival, v.Rule, v.Stay.from.Weekday())
+	case DepartureDayViolation:
+		return fmt.Sprintf("stay departing %s: rule %d does not allow departures on %s", departure.Format("2006-01-02"), v.Rule, departure.Weekday())
+	}
+	return fmt.Sprintf("stay arriving %s: breaks rule %d", arrival, v.Rule)
+}
+
+// Validate returns every rule restriction broken by the stay, in the order of
+// the rules. It returns an empty slice if the stay is allowed or empty.
+func (rules StayRules) Validate(stay DateRange) []StayViolation {
+	violations := []StayViolation{}
+	if stay.IsEmpty() {
+		return violations
+	}
+	arrival, departure := stay.from, stay.to.AddDate(0, 0, 1)
+	nights := stay.Days()
+	for i := range rules {
+		rule := &rules[i]
+		if rule.appliesTo(arrival) {
+			if rule.MinNights > 0 && nights < rule.MinNights {
+				violations = append(violations, StayViolation{Rule: i, Kind: MinNightsViolation, Stay: stay, Limit: rule.MinNights})
+			}
+			if rule.MaxNights > 0 && nights > rule.MaxNights {
+				violations = append(violations, StayViolation{Rule: i, Kind: MaxNightsViolation, Stay: stay, Limit: rule.MaxNights})
+			}
+			if !weekdayAllowed(arrival, rule.ArrivalDays) {
+				violations = append(violations, StayViolation{Rule: i, Kind: ArrivalDayViolation, Stay: stay})
+			}
+		}
+		if rule.appliesTo(departure) && !weekdayAllowed(departure, rule.DepartureDays) {
+			violations = append(violations, StayViolation{Rule: i, Kind: DepartureDayViolation, Stay: stay})
+		}
+	}
+	return violations
+}
+
+// IsAllowed returns true if the stay breaks no rule.
+func (rules StayRules) IsAllowed(stay DateRange) bool {
+	return len(rules.Validate(stay)) == 0
+}
+
+// CheckInDates returns the arrival dates within the window for which a stay of
+// the given number of nights breaks no rule. The stays may extend past the window.
+// An empty collection is returned for an unbounded window.
+func (rules StayRules) CheckInDates(window DateRange, nights int) DateRanges {
+	if window.IsEmpty() || !window.IsBounded() || nights < 1 {
+		return NewDateRanges()
+	}
+	return FromDateSeq(func(yield func(time.Time) bool) {
+		for date := window.from; !date.After(window.to); date = date.AddDate(0, 0, 1) {
+			stay := DateRange{from: date, to: date.AddDate(0, 0, nights-1), nonEmpty: true}
+			if rules.IsAllowed(stay) && !yield(date) {
+				return
+			}
+		}
+	})
+}
+
+// appliesTo returns true if the date is in the season of the rule.
+func (rule *StayRule) appliesTo(date time.Time) bool {
+	return rule.Season.IsZero() || rule.Season.Contains(date)
+}
+
+// weekdayAllowed returns true if the weekday of the date is one of the allowed
+// weekdays, or if no weekday is given.
+func weekdayAllowed(date time.Time, allowed []time.Weekday) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, wd := range allowed {
+		if date.Weekday() == wd {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+package daterange_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// stayRules returns "minimum 3 nights and arrival only on Saturdays in July"
+// and "at most 14 nights, no departures on Sundays".
+func stayRules() dr.StayRules {
+	return dr.StayRules{
+		{
+			Season:      dr.NewDateRanges(dr.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC))),
+			MinNights:   3,
+			ArrivalDays: []time.Weekday{time.Saturday},
+		},
+		{
+			MaxNights:     14,
+			DepartureDays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+		},
+	}
+}
+
+// test dr.StayRules.Validate
+func TestStayRulesValidate(t *testing.T) {
+	cases := []struct {
+		name string
+		stay dr.DateRange
+		want []dr.StayViolation
+	}{
+		{
+			name: "zero stay",
+			stay: dr.DateRange{},
+			want: []dr.StayViolation{},
+		},
+		{
+			name: "a week from Saturday",
+			stay: dr.NewDateRange(time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC)),
+			want: []dr.StayViolation{},
+		},
+		{
+			name: "short stay arriving on Monday",
+			stay: dr.NewDateRange(time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 9, 0, 0, 0, 0, time.UTC)),
+			want: []dr.StayViolation{
+				{Rule: 0, Kind: dr.MinNightsViolation, Stay: dr.NewDateRange(time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 9, 0, 0, 0, 0, time.UTC)), Limit: 3},
+				{Rule: 0, Kind: dr.ArrivalDayViolation, Stay: dr.NewDateRange(time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 9, 0, 0, 0, 0, time.UTC))},
+			},
+		},
+		{
+			name: "departing on Sunday",
+			stay: dr.NewDateRange(time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC)),
+			want: []dr.StayViolation{
+				{Rule: 1, Kind: dr.DepartureDayViolation, Stay: dr.NewDateRange(time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC))},
+			},
+		},
+		{
+			name: "too long",
+			stay: dr.NewDateRange(time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 26, 0, 0, 0, 0, time.UTC)),
+			want: []dr.StayViolation{
+				{Rule: 1, Kind: dr.MaxNightsViolation, Stay: dr.NewDateRange(time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 26, 0, 0, 0, 0, time.UTC)), Limit: 14},
+			},
+		},
+		{
+			name: "arriving before the season",
+			stay: dr.NewDateRange(time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)),
+			want: []dr.StayViolation{},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			rules := stayRules()
+			got := rules.Validate(c.stay)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Validate(%v) = %v, want %v", c.stay, got, c.want)
+			}
+			if allowed := rules.IsAllowed(c.stay); allowed != (len(c.want) == 0) {
+				t.Errorf("IsAllowed(%v) = %v, want %v", c.stay, allowed, len(c.want) == 0)
+			}
+		})
+	}
+}
+
+// test dr.StayViolation.Error
+func TestStayViolationError(t *testing.T) {
+	stay := dr.NewDateRange(time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC))
+	cases := []struct {
+		name string
+		v    dr.StayViolation
+		want string
+	}{
+		{
+			name: "min nights",
+			v:    dr.StayViolation{Rule: 0, Kind: dr.MinNightsViolation, Stay: stay, Limit: 7},
+			want: "stay arriving 2024-07-08: 6 nights, rule 0 requires at least 7",
+		},
+		{
+			name: "max nights",
+			v:    dr.StayViolation{Rule: 1, Kind: dr.MaxNightsViolation, Stay: stay, Limit: 5},
+			want: "stay arriving 2024-07-08: 6 nights, rule 1 allows at most 5",
+		},
+		{
+			name: "arrival day",
+			v:    dr.StayViolation{Rule: 0, Kind: dr.ArrivalDayViolation, Stay: stay},
+			want: "stay arriving 2024-07-08: rule 0 does not allow arrivals on Monday",
+		},
+		{
+			name: "departure day",
+			v:    dr.StayViolation{Rule: 2, Kind: dr.DepartureDayViolation, Stay: stay},
+			want: "stay departing 2024-07-14: rule 2 does not allow departures on Sunday",
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			var err error = c.v
+			if got := err.Error(); got != c.want {
+				t.Errorf("Error() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.StayRules.CheckInDates
+func TestStayRulesCheckInDates(t *testing.T) {
+	cases := []struct {
+		name   string
+		window dr.DateRange
+		nights int
+		want   []dr.DateRange
+	}{
+		{
+			name:   "zero window",
+			window: dr.DateRange{},
+			nights: 7,
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "invalid nights",
+			window: dr.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)),
+			nights: 0,
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "weeks in July",
+			window: dr.NewDateRange(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)),
+			nights: 7,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 7, 20, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 20, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 7, 27, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 27, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:   "around the start of the season",
+			window: dr.NewDateRange(time.Date(2024, 6, 26, 0, 0, 0, 0, time.UTC), time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)),
+			nights: 3,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 6, 26, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 26, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 6, 28, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			got := stayRules().CheckInDates(c.window, c.nights)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("CheckInDates(%v, %v) = %v, want %v", c.window, c.nights, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package daterange
+
+import "time"
+
+// LongestStreak returns the longest run of consecutive dates in the collection.
+// If several runs have the same length, the earliest one is returned.
+// It returns an empty DateRange if the collection is empty.
+func (drs *DateRanges) LongestStreak() DateRange {
+	longest := DateRange{}
+	for _, dr := range drs.dr {
+		if dr.Days() > longest.Days() {
+			longest = dr
+		}
+	}
+	return longest
+}
+
+// StreakEndingAt returns the run of consecutive dates of the collection that ends
+// on the given date, for example the current streak when date is today.
+// It returns an empty DateRange if the date is not in the collection.
+func (drs *DateRanges) StreakEndingAt(date time.Time) DateRange {
+	date = toDateUTC(date)
+	for _, dr := range drs.dr {
+		if dr.Contains(date) {
+			return DateRange{
+				from:     dr.from,
+				to:       date,
+				nonEmpty: true,
+			}
+		}
+	}
+	return DateRange{}
+}
+
+// StreaksOfAtLeast returns the runs of consecutive dates that are at least n days long.
+func (drs *DateRanges) StreaksOfAtLeast(n int) DateRanges {
+	streaks := []DateRange{}
+	for _, dr := range drs.dr {
+		if dr.Days() >= n {
+			streaks = append(streaks, dr)
+		}
+	}
+	return NewDateRanges(streaks...)
+}
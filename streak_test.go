@@ -0,0 +1,133 @@
+package daterange_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// test dr.DateRanges.LongestStreak
+func TestDateRangesLongestStreak(t *testing.T) {
+	cases := []struct {
+		name string
+		drs  []dr.DateRange
+		want dr.DateRange
+	}{
+		{
+			name: "empty",
+			drs:  []dr.DateRange{},
+			want: dr.DateRange{},
+		},
+		{
+			name: "longest in the middle",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)),
+			},
+			want: dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "tie returns earliest",
+			drs: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)),
+			},
+			want: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(c.drs...)
+			if got := drs.LongestStreak(); got != c.want {
+				t.Errorf("NewDateRanges(%v).LongestStreak() = %v, want %v", c.drs, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.StreakEndingAt
+func TestDateRangesStreakEndingAt(t *testing.T) {
+	drs := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)),
+	}
+	cases := []struct {
+		name string
+		date time.Time
+		want dr.DateRange
+	}{
+		{
+			name: "not in collection",
+			date: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+			want: dr.DateRange{},
+		},
+		{
+			name: "end of run",
+			date: time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+			want: dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "middle of run with time",
+			date: time.Date(2024, 1, 7, 18, 30, 0, 0, time.UTC),
+			want: dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "first day of run",
+			date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(drs...)
+			if got := drs.StreakEndingAt(c.date); got != c.want {
+				t.Errorf("NewDateRanges(%v).StreakEndingAt(%v) = %v, want %v", drs, c.date, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.DateRanges.StreaksOfAtLeast
+func TestDateRangesStreaksOfAtLeast(t *testing.T) {
+	drs := []dr.DateRange{
+		dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)),
+		dr.NewDateRange(time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)),
+	}
+	cases := []struct {
+		name string
+		n    int
+		want []dr.DateRange
+	}{
+		{
+			name: "all",
+			n:    0,
+			want: drs,
+		},
+		{
+			name: "at least 3",
+			n:    3,
+			want: drs[1:],
+		},
+		{
+			name: "none",
+			n:    6,
+			want: []dr.DateRange{},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			drs := dr.NewDateRanges(drs...)
+			got := drs.StreaksOfAtLeast(c.n)
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("NewDateRanges(%v).StreaksOfAtLeast(%v) = %v, want %v", drs, c.n, got, c.want)
+			}
+		})
+	}
+}
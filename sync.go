@@ -0,0 +1,121 @@
+package daterange
+
+import (
+	"sync"
+	"time"
+)
+
+// SyncDateRanges is a DateRanges that is safe for concurrent use by multiple goroutines.
+// Reads take a shared lock and writes an exclusive one, so compound operations such as
+// ReserveIfFree are atomic. The zero value is an empty collection ready to use.
+type SyncDateRanges struct {
+	mu  sync.RWMutex
+	drs DateRanges
+}
+
+// NewSyncDateRanges returns a new concurrency-safe collection with given elements
+func NewSyncDateRanges(dataRanges ...DateRange) *SyncDateRanges {
+	return &SyncDateRanges{
+		drs: NewDateRanges(dataRanges...),
+	}
+}
+
+// Snapshot returns a copy of the collection at the time of the call.
+// The copy is not affected by later changes.
+func (s *SyncDateRanges) Snapshot() DateRanges {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.drs.clone()
+}
+
+// String returns a string representation of the collection.
+func (s *SyncDateRanges) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.drs.String()
+}
+
+// Len returns the number of elements in the collection
+func (s *SyncDateRanges) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.drs.Len()
+}
+
+// Contains returns true if the given date is in the collection
+func (s *SyncDateRanges) Contains(date time.Time) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.drs.Contains(date)
+}
+
+// IsAnyDateIn returns true if any date in the given DateRange is in the collection
+// The empty DateRange is always considered to be in the collection
+func (s *SyncDateRanges) IsAnyDateIn(other DateRange) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.drs.IsAnyDateIn(other)
+}
+
+// IsAllDatesIn returns true if all dates in the given DateRange are in the collection
+// The empty DateRange is always considered to be in the collection
+func (s *SyncDateRanges) IsAllDatesIn(other DateRange) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.drs.IsAllDatesIn(other)
+}
+
+// Append adds the given elements to the collection
+func (s *SyncDateRanges) Append(dataRange ...DateRange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.drs.Append(dataRange...)
+}
+
+// Remove removes the dates of the given elements from the collection.
+func (s *SyncDateRanges) Remove(dataRange ...DateRange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.drs.Remove(dataRange...)
+}
+
+// ReserveIfFree adds the given DateRange to the collection if none of its dates
+// are in the collection yet, treating the collection as the set of occupied dates.
+// It returns true if the DateRange was added. An empty DateRange is never added.
+func (s *SyncDateRanges) ReserveIfFree(dr DateRange) bool {
+	if dr.IsEmpty() {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.drs.IsAnyDateIn(dr) {
+		return false
+	}
+	s.drs.Append(dr)
+	return true
+}
+
+// TakeIfAvailable removes the given DateRange from the collection if all of its
+// dates are in the collection, treating the collection as the set of available dates.
+// It returns true if the DateRange was removed. An empty DateRange is never removed.
+func (s *SyncDateRanges) TakeIfAvailable(dr DateRange) bool {
+	if dr.IsEmpty() {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.drs.IsAllDatesIn(dr) {
+		return false
+	}
+	s.drs.Remove(dr)
+	return true
+}
+
+// Update calls f with the collection while holding the write lock, so several
+// reads and changes are applied atomically. f must not retain the pointer or
+// call methods of s.
+func (s *SyncDateRanges) Update(f func(drs *DateRanges)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(&s.drs)
+}
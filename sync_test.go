@@ -0,0 +1,222 @@
+package daterange_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	dr "github.com/felixenescu/date-range"
+)
+
+// test dr.SyncDateRanges.ReserveIfFree
+func TestSyncDateRangesReserveIfFree(t *testing.T) {
+	cases := []struct {
+		name   string
+		drs    []dr.DateRange
+		dr     dr.DateRange
+		wantOk bool
+		want   []dr.DateRange
+	}{
+		{
+			name:   "empty",
+			drs:    []dr.DateRange{},
+			dr:     dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+			wantOk: true,
+			want:   []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:   "adjacent is free",
+			drs:    []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+			dr:     dr.NewDateRange(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)),
+			wantOk: true,
+			want:   []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:   "overlapping is not free",
+			drs:    []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+			dr:     dr.NewDateRange(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)),
+			wantOk: false,
+			want:   []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+		},
+		{
+			name:   "zero range",
+			drs:    []dr.DateRange{},
+			dr:     dr.DateRange{},
+			wantOk: false,
+			want:   []dr.DateRange{},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			s := dr.NewSyncDateRanges(c.drs...)
+			if got := s.ReserveIfFree(c.dr); got != c.wantOk {
+				t.Errorf("ReserveIfFree(%v) = %v, want %v", c.dr, got, c.wantOk)
+			}
+			got := s.Snapshot()
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("ReserveIfFree(%v) left %v, want %v", c.dr, got, c.want)
+			}
+		})
+	}
+}
+
+// test dr.SyncDateRanges.TakeIfAvailable
+func TestSyncDateRangesTakeIfAvailable(t *testing.T) {
+	cases := []struct {
+		name   string
+		drs    []dr.DateRange
+		dr     dr.DateRange
+		wantOk bool
+		want   []dr.DateRange
+	}{
+		{
+			name:   "empty",
+			drs:    []dr.DateRange{},
+			dr:     dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+			wantOk: false,
+			want:   []dr.DateRange{},
+		},
+		{
+			name:   "available",
+			drs:    []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))},
+			dr:     dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)),
+			wantOk: true,
+			want: []dr.DateRange{
+				dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+				dr.NewDateRange(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:   "partially available",
+			drs:    []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+			dr:     dr.NewDateRange(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)),
+			wantOk: false,
+			want:   []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))},
+		},
+	}
+	for _, c := range cases {
+		t.Logf("Running test %s", c.name)
+		t.Run(c.name, func(t *testing.T) {
+			s := dr.NewSyncDateRanges(c.drs...)
+			if got := s.TakeIfAvailable(c.dr); got != c.wantOk {
+				t.Errorf("TakeIfAvailable(%v) = %v, want %v", c.dr, got, c.wantOk)
+			}
+			got := s.Snapshot()
+			if !reflect.DeepEqual(got.ToSlice(), c.want) {
+				t.Errorf("TakeIfAvailable(%v) left %v, want %v", c.dr, got, c.want)
+			}
+		})
+	}
+}
+
+// test that dr.SyncDateRanges.Snapshot is not affected by later changes
+func TestSyncDateRangesSnapshot(t *testing.T) {
+	s := dr.NewSyncDateRanges(dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)))
+	snapshot := s.Snapshot()
+	s.Remove(dr.NewDateRange(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)))
+	s.Append(dr.NewDateRange(time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 12, 2, 0, 0, 0, 0, time.UTC)))
+	want := []dr.DateRange{dr.NewDateRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))}
+	if !reflect.DeepEqual(snapshot.ToSlice(), want) {
+		t.Errorf("Snapshot() = %v, want %v", snapshot, want)
+	}
+	if got := s.Len(); got != 3 {
+		t.Errorf("Len() = %v, want %v", got, 3)
+	}
+}
+
+// test concurrent dr.SyncDateRanges.ReserveIfFree, run with -race
+func TestSyncDateRangesConcurrentReserve(t *testing.T) {
+	s := dr.NewSyncDateRanges()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// every goroutine tries to reserve every 3-day slot, each slot must be won exactly once
+	const goroutines = 8
+	const slots = 50
+	won := make([]int, goroutines)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < slots; i++ {
+				from := start.AddDate(0, 0, 3*i)
+				if s.ReserveIfFree(dr.NewDateRange(from, from.AddDate(0, 0, 2))) {
+					won[g]++
+				}
+				s.Contains(from)
+				s.IsAllDatesIn(dr.NewDateRange(start, from))
+				_ = s.String()
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, n := range won {
+		total += n
+	}
+	if total != slots {
+		t.Errorf("ReserveIfFree succeeded %v times, want %v", total, slots)
+	}
+	want := []dr.DateRange{dr.NewDateRange(start, start.AddDate(0, 0, 3*slots-1))}
+	got := s.Snapshot()
+	if !reflect.DeepEqual(got.ToSlice(), want) {
+		t.Errorf("Snapshot() = %v, want %v", got, want)
+	}
+}
+
+// test concurrent dr.SyncDateRanges.Update and dr.SyncDateRanges.TakeIfAvailable, run with -race
+func TestSyncDateRangesConcurrentUpdate(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := dr.NewSyncDateRanges(dr.NewDateRange(start, start.AddDate(0, 0, 99)))
+
+	var wg sync.WaitGroup
+	taken := make(chan time.Time, 100)
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				date := start.AddDate(0, 0, i)
+				if s.TakeIfAvailable(dr.NewDateRange(date, date)) {
+					taken <- date
+				}
+			}
+		}()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				s.Update(func(drs *dr.DateRanges) {
+					// a read and a write that must see each other
+					before := drs.TotalDays()
+					drs.AddDate(start.AddDate(0, 0, 200))
+					drs.RemoveDate(start.AddDate(0, 0, 200))
+					if drs.TotalDays() != before {
+						t.Errorf("TotalDays() = %v, want %v", drs.TotalDays(), before)
+					}
+				})
+				snapshot := s.Snapshot()
+				snapshot.Append(dr.NewDateRange(start, start.AddDate(0, 0, 99)))
+			}
+		}()
+	}
+	wg.Wait()
+	close(taken)
+
+	seen := map[time.Time]bool{}
+	for date := range taken {
+		if seen[date] {
+			t.Errorf("TakeIfAvailable() took %v twice", date)
+		}
+		seen[date] = true
+	}
+	if len(seen) != 100 {
+		t.Errorf("TakeIfAvailable() took %v dates, want %v", len(seen), 100)
+	}
+	if got := s.Len(); got != 0 {
+		t.Errorf("Len() = %v, want %v", got, 0)
+	}
+}
@@ -25,7 +25,24 @@ SOFTWARE.
 
 package daterange
 
-import "time"
+import (
+	"math"
+	"time"
+)
+
+// negInf and posInf are the bounds of unbounded ranges. They compare before
+// and after every finite date, so set operations need no special cases.
+// firstDate and lastDate are the first and last finite dates, finite dates
+// beyond them are clamped to them.
+var (
+	negInf    = time.Date(-1000000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	posInf    = time.Date(1000000, time.December, 31, 0, 0, 0, 0, time.UTC)
+	firstDate = negInf.AddDate(0, 0, 1)
+	lastDate  = posInf.AddDate(0, 0, -1)
+)
+
+// dateFormat is the layout of dates in the string and JSON forms of ranges.
+const dateFormat = "2006-01-02"
 
 // maxTime returns the later of two time values.
 func maxTime(a, b time.Time) time.Time {
@@ -44,13 +61,101 @@ func minTime(a, b time.Time) time.Time {
 }
 
 // toDateUTC truncate a time to the date and set UTC location.
+// The infinite bounds are kept, dates beyond them are clamped to the first and
+// last finite dates, so a finite date never becomes an infinite bound.
 func toDateUTC(t time.Time) time.Time {
 	// the correct way to truncate a time to the date is to use
 	// time.Date with the zero values for the time components.
 	// The commonly used time.Truncate(24 * time.Hour) method does not work
 	// correctly for all cases, for example it ignores DST.
-	return time.Date(
+	date := time.Date(
 		t.Year(), t.Month(), t.Day(),
 		0, 0, 0, 0,
 		time.UTC)
+	switch {
+	case date.Before(negInf):
+		return firstDate
+	case date.After(posInf):
+		return lastDate
+	}
+	return date
+}
+
+// isNegInf returns true if the date is the negative infinite bound.
+func isNegInf(date time.Time) bool {
+	return !date.After(negInf)
+}
+
+// isPosInf returns true if the date is the positive infinite bound.
+func isPosInf(date time.Time) bool {
+	return !date.Before(posInf)
+}
+
+// addDays adds n days to a date. Infinite bounds are left unchanged and finite
+// dates are clamped to the first and last finite dates. n saturates before the
+// addition, as time.AddDate wraps around for very large values.
+func addDays(date time.Time, n int) time.Time {
+	if isNegInf(date) || isPosInf(date) {
+		return date
+	}
+	if n >= daysBetween(date, lastDate) {
+		return lastDate
+	}
+	if n <= daysBetween(date, firstDate) {
+		return firstDate
+	}
+	return date.AddDate(0, 0, n)
+}
+
+// addSaturated adds two non-negative day counts, saturating at math.MaxInt.
+func addSaturated(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+// formatBound formats a bound of a range as a date, or "-inf" and "+inf" for
+// the infinite bounds.
+func formatBound(date time.Time) string {
+	switch {
+	case isNegInf(date):
+		return "-inf"
+	case isPosInf(date):
+		return "+inf"
+	}
+	return date.Format(dateFormat)
+}
+
+// parseBound parses a bound formatted by formatBound.
+func parseBound(value string) (time.Time, error) {
+	switch value {
+	case "-inf":
+		return negInf, nil
+	case "+inf":
+		return posInf, nil
+	}
+	date, err := time.Parse(dateFormat, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return toDateUTC(date), nil
+}
+
+// daysBetween returns the number of days from a to b, both truncated to the date.
+// Unix seconds are used because time.Duration cannot hold more than about 292 years.
+func daysBetween(a, b time.Time) int {
+	return int((toDateUTC(b).Unix() - toDateUTC(a).Unix()) / (24 * 60 * 60))
+}
+
+// addMonthsClamped adds the given number of months to a date, clamping the day
+// to the last day of the resulting month, for example 2024-01-31 plus one month
+// is 2024-02-29. The date must already be truncated to midnight UTC.
+func addMonthsClamped(date time.Time, months int) time.Time {
+	first := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	day := date.Day()
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
 }
@@ -0,0 +1,88 @@
+package daterange
+
+// WindowAnchor selects which end of the range the windows of DateRange.Windows are aligned to.
+type WindowAnchor int
+
+const (
+	// AnchorStart aligns the first window with the first date of the range.
+	// Partial windows can only occur at the end of the range.
+	AnchorStart WindowAnchor = iota
+	// AnchorEnd aligns the last window with the last date of the range.
+	// Partial windows can only occur at the start of the range.
+	AnchorEnd
+)
+
+// WindowOptions configures the windows generated by DateRange.Windows.
+type WindowOptions struct {
+	Step        int          // days between the starts of consecutive windows, values less than 1 default to the window size
+	Anchor      WindowAnchor // end of the range the windows are aligned to
+	KeepPartial bool         // keep windows shorter than the window size, clipped to the range
+}
+
+// Windows returns an iterator over the windows of size days within the range,
+// in ascending order. With the default Step every window starts right after
+// the previous one (tumbling windows); a Step smaller than size gives sliding
+// windows. Windows are generated lazily, so long ranges are not allocated up front.
+// An empty or unbounded range has no windows.
+//
+// The iterator has the signature of iter.Seq[DateRange] and can be used with
+// range-over-func on Go 1.23 or later, or called directly with a yield function
+// that returns false to stop the iteration.
+func (d DateRange) Windows(size int, opts WindowOptions) func(yield func(DateRange) bool) {
+	return func(yield func(DateRange) bool) {
+		if d.IsEmpty() || !d.IsBounded() || size < 1 {
+			return
+		}
+		last := daysBetween(d.from, d.to)
+		// every window longer than the range is partial, so clamp the size
+		// to avoid overflows in the window ends
+		if size > last+1 {
+			size = last + 2
+		}
+		step := opts.Step
+		if step < 1 {
+			step = size
+		}
+
+		window := func(start, end int) DateRange {
+			if start < 0 {
+				start = 0
+			}
+			if end > last {
+				end = last
+			}
+			return DateRange{
+				from:     d.from.AddDate(0, 0, start),
+				to:       d.from.AddDate(0, 0, end),
+				nonEmpty: true,
+			}
+		}
+
+		if opts.Anchor == AnchorEnd {
+			// windows end on last, last-step, last-2*step, ... and are yielded backwards
+			count := (last + 1 - size) / step
+			if opts.KeepPartial {
+				count = last / step
+			} else if last+1 < size {
+				return
+			}
+			for k := count; k >= 0; k-- {
+				end := last - k*step
+				if !yield(window(end-size+1, end)) {
+					return
+				}
+			}
+			return
+		}
+
+		for start := 0; start <= last; start += step {
+			end := start + size - 1
+			if end > last && !opts.KeepPartial {
+				return
+			}
+			if !yield(window(start, end)) {
+				return
+			}
+		}
+	}
+}